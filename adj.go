@@ -13,6 +13,8 @@ package graph
 import (
 	"math"
 	"sort"
+
+	"github.com/soniakeys/bits"
 )
 
 // NI is a "node int"
@@ -143,6 +145,9 @@ func (g LabeledAdjacencyList) ArcsAsEdges() (el []LabeledEdge) {
 // If g is an undirected graph with no negative edge weights, the result
 // array will be a distance matrix, for example as used by package
 // github.com/soniakeys/cluster.
+//
+// Where there is no path from node i to node j, d[i][j] is +Inf.  See the
+// generic function FloydWarshall for other weight types.
 func (g LabeledAdjacencyList) FloydWarshall(w WeightFunc) (d [][]float64) {
 	d = newFWd(len(g))
 	for fr, to := range g {
@@ -183,6 +188,49 @@ func solveFW(d [][]float64) {
 	}
 }
 
+// FloydWarshall finds all pairs shortest distances for a simple weighted
+// graph without negative cycles.
+//
+// It is the generic version of LabeledAdjacencyList.FloydWarshall,
+// accepting any Weight type.  As Weight types need not have an infinite
+// value, path existence is returned separately.  Bit j of path[i] is 1 if
+// there is a path from node i to node j.  Where there is no path, d[i][j]
+// is zero.
+func FloydWarshall[W Weight](g LabeledAdjacencyList, w func(LI) W) (d [][]W, path []bits.Bits) {
+	d = make([][]W, len(g))
+	path = make([]bits.Bits, len(g))
+	for i := range d {
+		d[i] = make([]W, len(g))
+		path[i] = bits.New(len(g))
+		path[i].SetBit(i, 1)
+	}
+	for fr, to := range g {
+		for _, to := range to {
+			d[fr][to.To] = w(to.Label)
+			path[fr].SetBit(int(to.To), 1)
+		}
+	}
+	// solve
+	for k, dk := range d {
+		pk := path[k]
+		for i, di := range d {
+			pi := &path[i]
+			if pi.Bit(k) == 0 {
+				continue
+			}
+			dik := di[k]
+			pk.IterateOnes(func(j int) bool {
+				if d2 := dik + dk[j]; pi.Bit(j) == 0 || d2 < di[j] {
+					di[j] = d2
+					pi.SetBit(j, 1)
+				}
+				return true
+			})
+		}
+	}
+	return
+}
+
 // HasArcLabel returns true if g has any arc from node `fr` to node `to`
 // with label `l`.
 //
@@ -8,6 +8,7 @@ package graph
 // DO NOT EDIT adj_RO.go.  The RO is for Read Only.
 
 import (
+	"iter"
 	"math/rand"
 
 	"github.com/soniakeys/bits"
@@ -38,6 +39,28 @@ func (g LabeledAdjacencyList) ArcSize() int {
 	return m
 }
 
+// ArcSubgraph returns the subgraph of g containing only arcs accepted by
+// function keep.
+//
+// Keep is called for each arc of g, with the from-node and the arc.  The
+// returned graph has the same nodes as g and a fresh copy of each accepted
+// arc.  Node numbers are unchanged so no mapping is needed.  Note that for
+// an undirected graph, keep must accept both or neither of the reciprocal
+// arcs of an edge for the result to remain undirected.
+//
+// There are equivalent labeled and unlabeled versions of this method.
+func (g LabeledAdjacencyList) ArcSubgraph(keep func(fr NI, to Half) bool) LabeledAdjacencyList {
+	s := make(LabeledAdjacencyList, len(g))
+	for fr, to := range g {
+		for _, to := range to {
+			if keep(NI(fr), to) {
+				s[fr] = append(s[fr], to)
+			}
+		}
+	}
+	return s
+}
+
 // BoundsOk validates that all arcs in g stay within the slice bounds of g.
 //
 // BoundsOk returns true when no arcs point outside the bounds of g.
@@ -150,6 +173,20 @@ func (g LabeledAdjacencyList) BreadthFirst(start NI, opt ...TraverseOption) {
 	}
 }
 
+// BreadthFirstSeq returns an iterator over nodes in breadth first order.
+//
+// Nodes are yielded in the order they would be passed to a NodeVisitor of
+// BreadthFirst.  Arguments are as for BreadthFirst except that options
+// NodeVisitor and OkNodeVisitor should not be given.  Breaking out of a
+// range loop over the iterator terminates the traversal.
+//
+// There are equivalent labeled and unlabeled versions of this method.
+func (g LabeledAdjacencyList) BreadthFirstSeq(start NI, opt ...TraverseOption) iter.Seq[NI] {
+	return func(yield func(NI) bool) {
+		g.BreadthFirst(start, append(opt[:len(opt):len(opt)], OkNodeVisitor(yield))...)
+	}
+}
+
 // Copy makes a deep copy of g.
 // Copy also computes the arc size ma, the number of arcs.
 //
@@ -258,6 +295,35 @@ func (g LabeledAdjacencyList) DepthFirst(start NI, options ...TraverseOption) {
 	df(cf.start)
 }
 
+// DepthFirstSeq returns an iterator over nodes in depth first order.
+//
+// Nodes are yielded in the order they would be passed to a NodeVisitor of
+// DepthFirst.  Arguments are as for DepthFirst except that options
+// NodeVisitor and OkNodeVisitor should not be given.  Breaking out of a
+// range loop over the iterator terminates the traversal.
+//
+// There are equivalent labeled and unlabeled versions of this method.
+func (g LabeledAdjacencyList) DepthFirstSeq(start NI, opt ...TraverseOption) iter.Seq[NI] {
+	return func(yield func(NI) bool) {
+		g.DepthFirst(start, append(opt[:len(opt):len(opt)], OkNodeVisitor(yield))...)
+	}
+}
+
+// DepthFirstArcSeq returns an iterator over arcs in depth first order.
+//
+// Arcs are yielded as the pairs n, x that would be passed to an ArcVisitor
+// of DepthFirst, where x is the index of the arc in the arc list of node n.
+// Arguments are as for DepthFirst except that options ArcVisitor and
+// OkArcVisitor should not be given.  Breaking out of a range loop over the
+// iterator terminates the traversal.
+//
+// There are equivalent labeled and unlabeled versions of this method.
+func (g LabeledAdjacencyList) DepthFirstArcSeq(start NI, opt ...TraverseOption) iter.Seq2[NI, int] {
+	return func(yield func(NI, int) bool) {
+		g.DepthFirst(start, append(opt[:len(opt):len(opt)], OkArcVisitor(yield))...)
+	}
+}
+
 // HasArc returns true if g has any arc from node `fr` to node `to`.
 //
 // Also returned is the index within the slice of arcs from node `fr`.
@@ -348,6 +414,67 @@ func (g LabeledAdjacencyList) IsSimple() (ok bool, n NI) {
 	return true, -1
 }
 
+// InducedSubgraph returns the subgraph of g induced by a list of nodes.
+//
+// The induced subgraph contains the listed nodes and all arcs of g between
+// them.  Nodes of the subgraph are numbered in the order of argument nodes,
+// which must not contain duplicates.
+//
+// Return value toSub maps node numbers of g to node numbers of s, with -1
+// for nodes not in the subgraph.  It has length len(g).  Return value toG
+// maps node numbers of s back to node numbers of g.  It is a copy of
+// argument nodes.
+//
+// See also InducedSubgraphBits.
+//
+// There are equivalent labeled and unlabeled versions of this method.
+func (g LabeledAdjacencyList) InducedSubgraph(nodes []NI) (s LabeledAdjacencyList, toSub, toG []NI) {
+	toSub = make([]NI, len(g))
+	for n := range toSub {
+		toSub[n] = -1
+	}
+	for sn, n := range nodes {
+		toSub[n] = NI(sn)
+	}
+	toG = append([]NI{}, nodes...)
+	return g.induced(toSub, toG), toSub, toG
+}
+
+// InducedSubgraphBits returns the subgraph of g induced by a set of nodes.
+//
+// It is equivalent to InducedSubgraph with a list of nodes in ascending
+// order.  Argument nodes must have Num equal to len(g).  Bitmaps such as
+// those emitted by BronKerbosch methods are suitable.
+//
+// There are equivalent labeled and unlabeled versions of this method.
+func (g LabeledAdjacencyList) InducedSubgraphBits(nodes bits.Bits) (s LabeledAdjacencyList, toSub, toG []NI) {
+	toSub = make([]NI, len(g))
+	for n := range toSub {
+		toSub[n] = -1
+	}
+	nodes.IterateOnes(func(n int) bool {
+		toSub[n] = NI(len(toG))
+		toG = append(toG, NI(n))
+		return true
+	})
+	return g.induced(toSub, toG), toSub, toG
+}
+
+// induced constructs the induced subgraph for InducedSubgraph and
+// InducedSubgraphBits.
+func (g LabeledAdjacencyList) induced(toSub, toG []NI) LabeledAdjacencyList {
+	s := make(LabeledAdjacencyList, len(toG))
+	for sn, n := range toG {
+		for _, to := range g[n] {
+			if st := toSub[to.To]; st >= 0 {
+				to.To = st
+				s[sn] = append(s[sn], to)
+			}
+		}
+	}
+	return s
+}
+
 // IsolatedNodes returns a bitmap of isolated nodes in receiver graph g.
 //
 // An isolated node is one with no arcs going to or from it.
@@ -17,6 +17,7 @@ import (
 	"sort"
 	"testing"
 
+	"github.com/soniakeys/bits"
 	"github.com/soniakeys/graph"
 )
 
@@ -67,6 +68,32 @@ func ExampleLabeledAdjacencyList_ArcSize() {
 	// 5
 }
 
+func ExampleLabeledAdjacencyList_ArcSubgraph() {
+	//    0                                 0
+	//  x/ \y   keep arcs with label < 'z'  x/ \y
+	//  1<->2   gives                      1<--2
+	//   z w                                 w
+	g := graph.LabeledAdjacencyList{
+		0: {{1, 'x'}, {2, 'y'}},
+		1: {{2, 'z'}},
+		2: {{1, 'w'}},
+	}
+	s := g.ArcSubgraph(func(fr graph.NI, to graph.Half) bool {
+		return to.Label < 'z'
+	})
+	for fr, to := range s {
+		fmt.Print(fr, ":")
+		for _, to := range to {
+			fmt.Printf(" (%d %c)", to.To, to.Label)
+		}
+		fmt.Println()
+	}
+	// Output:
+	// 0: (1 x) (2 y)
+	// 1:
+	// 2: (1 w)
+}
+
 func ExampleLabeledAdjacencyList_BoundsOk() {
 	var g graph.LabeledAdjacencyList
 	ok, _, _ := g.BoundsOk() // zero value adjacency list is valid
@@ -129,6 +156,58 @@ func ExampleLabeledAdjacencyList_AnyParallelMap_noParallelArcs() {
 	// false -1 -1
 }
 
+func ExampleLabeledAdjacencyList_InducedSubgraph() {
+	//    0
+	//  x/ \y    nodes [3 1 2] induce    0-w->1
+	//  1-z>2                            ^   /
+	//  ^  /                            v|  /z
+	// w| /u                             | v
+	//   3                                2
+	g := graph.LabeledAdjacencyList{
+		0: {{1, 'x'}, {2, 'y'}},
+		1: {{2, 'z'}},
+		2: {{3, 'u'}},
+		3: {{1, 'w'}},
+	}
+	s, toSub, toG := g.InducedSubgraph([]graph.NI{3, 1, 2})
+	for fr, to := range s {
+		fmt.Print(fr, ":")
+		for _, to := range to {
+			fmt.Printf(" (%d %c)", to.To, to.Label)
+		}
+		fmt.Println()
+	}
+	fmt.Println(toSub, toG)
+	// Output:
+	// 0: (1 w)
+	// 1: (2 z)
+	// 2: (0 u)
+	// [-1 1 2 0] [3 1 2]
+}
+
+func ExampleLabeledAdjacencyList_InducedSubgraphBits() {
+	g := graph.LabeledAdjacencyList{
+		0: {{1, 'x'}, {2, 'y'}},
+		1: {{2, 'z'}},
+		2: {{3, 'u'}},
+		3: {{1, 'w'}},
+	}
+	s, toSub, toG := g.InducedSubgraphBits(bits.NewGivens(0, 2, 3))
+	for fr, to := range s {
+		fmt.Print(fr, ":")
+		for _, to := range to {
+			fmt.Printf(" (%d %c)", to.To, to.Label)
+		}
+		fmt.Println()
+	}
+	fmt.Println(toSub, toG)
+	// Output:
+	// 0: (1 y)
+	// 1: (2 u)
+	// 2:
+	// [0 -1 1 2] [0 2 3]
+}
+
 func ExampleLabeledAdjacencyList_IsolatedNodes() {
 	//   0  1
 	//  / \
@@ -21,6 +21,7 @@ import (
 	"sort"
 	"testing"
 
+	"github.com/soniakeys/bits"
 	"github.com/soniakeys/graph"
 )
 
@@ -71,6 +72,21 @@ func ExampleAdjacencyList_ArcSize() {
 	// 5
 }
 
+func ExampleAdjacencyList_ArcSubgraph() {
+	//    0                            0
+	//   / \   keep arcs fr < to      / \
+	//  1<->2  gives                 1-->2
+	g := graph.AdjacencyList{
+		0: {1, 2},
+		1: {2},
+		2: {1},
+	}
+	s := g.ArcSubgraph(func(fr, to graph.NI) bool { return fr < to })
+	fmt.Println(s)
+	// Output:
+	// [[1 2] [2] []]
+}
+
 func ExampleAdjacencyList_BoundsOk() {
 	var g graph.AdjacencyList
 	ok, _, _ := g.BoundsOk() // zero value adjacency list is valid
@@ -174,6 +190,59 @@ func ExampleAdjacencyList_BreadthFirst_traverse() {
 	// visit 8 level 3
 }
 
+func ExampleAdjacencyList_BreadthFirstSeq() {
+	// arcs directed down
+	//    0--
+	//   /|  \
+	//  1 2   3
+	//   /|\  |\
+	//  4 5 6 7 8
+	g := graph.AdjacencyList{
+		0: {1, 2, 3},
+		2: {4, 5, 6},
+		3: {7, 8},
+		8: {},
+	}
+	var f graph.FromList
+	for n := range g.BreadthFirstSeq(0, graph.From(&f)) {
+		if f.Paths[n].Len > 2 {
+			break
+		}
+		fmt.Println("visit", n, "level", f.Paths[n].Len)
+	}
+	// Output:
+	// visit 0 level 1
+	// visit 1 level 2
+	// visit 2 level 2
+	// visit 3 level 2
+}
+
+func ExampleAdjacencyList_DepthFirstArcSeq() {
+	// arcs directed down
+	//    0--
+	//   /|  \
+	//  1 2   3
+	//   /|\  |\
+	//  4 5 6 7 8
+	g := graph.AdjacencyList{
+		0: {1, 2, 3},
+		2: {4, 5, 6},
+		3: {7, 8},
+		8: {},
+	}
+	for n, x := range g.DepthFirstArcSeq(0) {
+		fmt.Println(n, "->", g[n][x])
+		if g[n][x] == 5 {
+			break
+		}
+	}
+	// Output:
+	// 0 -> 1
+	// 0 -> 2
+	// 2 -> 4
+	// 2 -> 5
+}
+
 func ExampleAdjacencyList_BreadthFirst_traverseRandom() {
 	// arcs directed down
 	//    0--
@@ -257,6 +326,42 @@ func ExampleAdjacencyList_AnyParallelMap_noParallelArcs() {
 	// false -1 -1
 }
 
+func ExampleAdjacencyList_InducedSubgraph() {
+	//    0
+	//   / \    nodes [3 1 2] induce   0-->1
+	//  1-->2                           ^  /
+	//  ^  /                            | v
+	//  | v                              2
+	//   3
+	g := graph.AdjacencyList{
+		0: {1, 2},
+		1: {2},
+		2: {3},
+		3: {1},
+	}
+	s, toSub, toG := g.InducedSubgraph([]graph.NI{3, 1, 2})
+	fmt.Println(s)
+	fmt.Println(toSub, toG)
+	// Output:
+	// [[1] [2] [0]]
+	// [-1 1 2 0] [3 1 2]
+}
+
+func ExampleAdjacencyList_InducedSubgraphBits() {
+	g := graph.AdjacencyList{
+		0: {1, 2},
+		1: {2},
+		2: {3},
+		3: {1},
+	}
+	s, toSub, toG := g.InducedSubgraphBits(bits.NewGivens(0, 2, 3))
+	fmt.Println(s)
+	fmt.Println(toSub, toG)
+	// Output:
+	// [[1] [2] []]
+	// [0 -1 1 2] [0 2 3]
+}
+
 func ExampleAdjacencyList_IsolatedNodes() {
 	//   0  1
 	//  / \
@@ -85,6 +85,24 @@ func ExampleLabeledAdjacencyList_FloydWarshall() {
 	// [ 2  5  1  0]
 }
 
+func ExampleFloydWarshall() {
+	g := graph.LabeledAdjacencyList{
+		0: {{To: 2, Label: -1}},
+		1: {{To: 3, Label: -2}},
+		2: {{To: 1, Label: 4}, {To: 3, Label: 3}},
+		3: {},
+	}
+	d, path := graph.FloydWarshall(g, func(l graph.LI) int64 { return int64(l) })
+	for i, di := range d {
+		fmt.Printf("%2d %s\n", di, path[i])
+	}
+	// Output:
+	// [ 0  3 -1  1] 1111
+	// [ 0  0  0 -2] 1010
+	// [ 0  4  0  2] 1110
+	// [ 0  0  0  0] 1000
+}
+
 func ExampleLabeledDirected_FromListLabels() {
 	//      0
 	// 'A' / \ 'B'
@@ -0,0 +1,320 @@
+// Copyright 2026 Sonia Keys
+// License MIT: https://opensource.org/licenses/MIT
+
+package graph
+
+// algebra.go -- graph operations combining or transforming whole graphs.
+//
+// Operations are implemented on AdjacencyList and wrapped as methods of
+// Directed and Undirected.  For products, node (u, v) of the product of
+// g and h, with u a node of g and v a node of h, is numbered
+// u*len(h) + v.
+
+import (
+	"github.com/soniakeys/bits"
+)
+
+// disjointUnion returns g and h as a single graph, with nodes of h
+// numbered after nodes of g.
+func (g AdjacencyList) disjointUnion(h AdjacencyList) AdjacencyList {
+	u := make(AdjacencyList, len(g)+len(h))
+	for fr, to := range g {
+		u[fr] = append([]NI{}, to...)
+	}
+	off := NI(len(g))
+	for fr, to := range h {
+		if len(to) == 0 {
+			continue
+		}
+		ut := make([]NI, len(to))
+		for x, to := range to {
+			ut[x] = to + off
+		}
+		u[off+NI(fr)] = ut
+	}
+	return u
+}
+
+// join returns the disjoint union of g and h with additional arcs in both
+// directions between every node of g and every node of h.
+func (g AdjacencyList) join(h AdjacencyList) AdjacencyList {
+	j := g.disjointUnion(h)
+	off := len(g)
+	for n1 := range g {
+		for n2 := off; n2 < len(j); n2++ {
+			j[n1] = append(j[n1], NI(n2))
+			j[n2] = append(j[n2], NI(n1))
+		}
+	}
+	return j
+}
+
+// product returns the Cartesian product, tensor product, or the union of
+// the two, which is the strong product.
+func (g AdjacencyList) product(h AdjacencyList, cartesian, tensor bool) AdjacencyList {
+	nh := NI(len(h))
+	p := make(AdjacencyList, len(g)*len(h))
+	for u, gt := range g {
+		for v, ht := range h {
+			fr := NI(u)*nh + NI(v)
+			var to []NI
+			if cartesian {
+				for _, u2 := range gt {
+					to = append(to, u2*nh+NI(v))
+				}
+				for _, v2 := range ht {
+					to = append(to, NI(u)*nh+v2)
+				}
+			}
+			if tensor {
+				for _, u2 := range gt {
+					for _, v2 := range ht {
+						to = append(to, u2*nh+v2)
+					}
+				}
+			}
+			p[fr] = to
+		}
+	}
+	return p
+}
+
+// complement returns the graph with arcs between distinct nodes exactly
+// where g has none.
+func (g AdjacencyList) complement() AdjacencyList {
+	c := make(AdjacencyList, len(g))
+	b := bits.New(len(g))
+	for fr, to := range g {
+		b.SetAll()
+		b.SetBit(fr, 0)
+		for _, to := range to {
+			b.SetBit(int(to), 0)
+		}
+		b.IterateOnes(func(n int) bool {
+			c[fr] = append(c[fr], NI(n))
+			return true
+		})
+	}
+	return c
+}
+
+// power returns the graph with an arc from each node to each other node
+// reachable by a path of k or fewer arcs.
+func (g AdjacencyList) power(k int) AdjacencyList {
+	p := make(AdjacencyList, len(g))
+	if k < 1 {
+		return p
+	}
+	dist := make([]int, len(g))
+	for start := range g {
+		for n := range dist {
+			dist[n] = -1
+		}
+		dist[start] = 0
+		frontier := []NI{NI(start)}
+		for d := 1; d <= k && len(frontier) > 0; d++ {
+			var next []NI
+			for _, n := range frontier {
+				for _, to := range g[n] {
+					if dist[to] < 0 {
+						dist[to] = d
+						next = append(next, to)
+						p[start] = append(p[start], to)
+					}
+				}
+			}
+			frontier = next
+		}
+	}
+	return p
+}
+
+// DisjointUnion returns the disjoint union of g and h.
+//
+// Nodes of g keep their node numbers.  Node n of h becomes node
+// len(g.AdjacencyList) + n of the result.  The result shares no memory
+// with g or h.
+func (g Directed) DisjointUnion(h Directed) Directed {
+	return Directed{g.AdjacencyList.disjointUnion(h.AdjacencyList)}
+}
+
+// Join returns the join of g and h.
+//
+// The join is the disjoint union of g and h with additional arcs in both
+// directions between every node of g and every node of h.  Nodes are
+// numbered as for DisjointUnion.
+func (g Directed) Join(h Directed) Directed {
+	return Directed{g.AdjacencyList.join(h.AdjacencyList)}
+}
+
+// CartesianProduct returns the Cartesian product of g and h.
+//
+// Node (u, v) of the product, with u a node of g and v a node of h, is
+// numbered u*h.Order() + v.  There is an arc from (u, v) to (u2, v) for each
+// arc from u to u2 in g and an arc from (u, v) to (u, v2) for each arc from
+// v to v2 in h.
+//
+// The Cartesian product of two paths for example is a grid.
+func (g Directed) CartesianProduct(h Directed) Directed {
+	return Directed{g.AdjacencyList.product(h.AdjacencyList, true, false)}
+}
+
+// TensorProduct returns the tensor product of g and h.
+//
+// Nodes are numbered as for CartesianProduct.  There is an arc from
+// (u, v) to (u2, v2) for each arc from u to u2 in g and arc from v to v2
+// in h.
+func (g Directed) TensorProduct(h Directed) Directed {
+	return Directed{g.AdjacencyList.product(h.AdjacencyList, false, true)}
+}
+
+// StrongProduct returns the strong product of g and h.
+//
+// Nodes are numbered as for CartesianProduct.  Arcs are the union of arcs
+// of the Cartesian product and the tensor product.
+func (g Directed) StrongProduct(h Directed) Directed {
+	return Directed{g.AdjacencyList.product(h.AdjacencyList, true, true)}
+}
+
+// Complement returns the complement of a simple directed graph.
+//
+// The result has an arc from n1 to n2 for distinct nodes n1 and n2 exactly
+// when g has no arc from n1 to n2.  The result has no loops.  Arc lists are
+// in ascending order.
+func (g Directed) Complement() Directed {
+	return Directed{g.AdjacencyList.complement()}
+}
+
+// Power returns the k-th power of g.
+//
+// The result has an arc from n1 to n2 for distinct nodes n1 and n2 when g
+// has a path of k or fewer arcs from n1 to n2.  The result is simple.
+// If k is less than 1, the result has no arcs.
+func (g Directed) Power(k int) Directed {
+	return Directed{g.AdjacencyList.power(k)}
+}
+
+// DisjointUnion returns the disjoint union of g and h.
+//
+// Nodes of g keep their node numbers.  Node n of h becomes node
+// len(g.AdjacencyList) + n of the result.  The result shares no memory
+// with g or h.
+func (g Undirected) DisjointUnion(h Undirected) Undirected {
+	return Undirected{g.AdjacencyList.disjointUnion(h.AdjacencyList)}
+}
+
+// Join returns the join of g and h.
+//
+// The join is the disjoint union of g and h with additional edges between
+// every node of g and every node of h.  Nodes are numbered as for
+// DisjointUnion.
+//
+// The join of two edgeless graphs for example is a complete bipartite graph.
+func (g Undirected) Join(h Undirected) Undirected {
+	return Undirected{g.AdjacencyList.join(h.AdjacencyList)}
+}
+
+// CartesianProduct returns the Cartesian product of g and h.
+//
+// Node (u, v) of the product, with u a node of g and v a node of h, is
+// numbered u*h.Order() + v.  There is an edge between (u, v) and (u2, v)
+// for each edge between u and u2 in g and an edge between (u, v) and
+// (u, v2) for each edge between v and v2 in h.
+//
+// The Cartesian product of two paths for example is a grid.
+func (g Undirected) CartesianProduct(h Undirected) Undirected {
+	return Undirected{g.AdjacencyList.product(h.AdjacencyList, true, false)}
+}
+
+// TensorProduct returns the tensor product of g and h.
+//
+// Nodes are numbered as for CartesianProduct.  There is an edge between
+// (u, v) and (u2, v2) for each edge between u and u2 in g and edge between
+// v and v2 in h.
+func (g Undirected) TensorProduct(h Undirected) Undirected {
+	return Undirected{g.AdjacencyList.product(h.AdjacencyList, false, true)}
+}
+
+// StrongProduct returns the strong product of g and h.
+//
+// Nodes are numbered as for CartesianProduct.  Edges are the union of edges
+// of the Cartesian product and the tensor product.
+func (g Undirected) StrongProduct(h Undirected) Undirected {
+	return Undirected{g.AdjacencyList.product(h.AdjacencyList, true, true)}
+}
+
+// Complement returns the complement of a simple undirected graph.
+//
+// The result has an edge between distinct nodes n1 and n2 exactly when g
+// has no edge between n1 and n2.  The result has no loops.  Arc lists are
+// in ascending order.
+func (g Undirected) Complement() Undirected {
+	return Undirected{g.AdjacencyList.complement()}
+}
+
+// Power returns the k-th power of g.
+//
+// The result has an edge between distinct nodes n1 and n2 when g has a
+// path of k or fewer edges between n1 and n2.  The result is simple.
+// If k is less than 1, the result has no edges.
+func (g Undirected) Power(k int) Undirected {
+	return Undirected{g.AdjacencyList.power(k)}
+}
+
+// LineGraph returns the line graph of g.
+//
+// Each edge of g becomes a node of the line graph l.  Nodes of l are
+// adjacent when the corresponding edges of g share an end point.
+//
+// Return value edges maps nodes of l to edges of g.  Edges are listed with
+// N1 <= N2, in order of N1 and then position of the arc in g[N1].  Return
+// value arcNode maps arcs of g to nodes of l.  It is parallel to
+// g.AdjacencyList, so arcNode[n][x] is the node of l corresponding to the
+// edge of arc g.AdjacencyList[n][x].  Reciprocal arcs map to the same node.
+//
+// A loop in g becomes a node of l adjacent to the other edges at the node
+// of the loop.  Parallel edges in g become nodes of l with parallel edges
+// between them, one for each shared end point.
+func (g Undirected) LineGraph() (l Undirected, edges []Edge, arcNode [][]NI) {
+	a := g.AdjacencyList
+	arcNode = make([][]NI, len(a))
+	for fr, to := range a {
+		arcNode[fr] = make([]NI, len(to))
+		for x := range arcNode[fr] {
+			arcNode[fr][x] = -1
+		}
+	}
+	// incident lists line graph nodes at each node of g.
+	incident := make([][]NI, len(a))
+	for fr, to := range a {
+		for x, to := range to {
+			if arcNode[fr][x] >= 0 {
+				continue // already paired
+			}
+			ln := NI(len(edges))
+			edges = append(edges, Edge{NI(fr), to})
+			arcNode[fr][x] = ln
+			incident[fr] = append(incident[fr], ln)
+			if to == NI(fr) {
+				continue // loop
+			}
+			incident[to] = append(incident[to], ln)
+			// pair with first unpaired reciprocal
+			for y, r := range a[to] {
+				if r == NI(fr) && arcNode[to][y] < 0 {
+					arcNode[to][y] = ln
+					break
+				}
+			}
+		}
+	}
+	l.AdjacencyList = make(AdjacencyList, len(edges))
+	for _, inc := range incident {
+		for i, n1 := range inc {
+			for _, n2 := range inc[i+1:] {
+				l.AddEdge(n1, n2)
+			}
+		}
+	}
+	return
+}
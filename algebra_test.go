@@ -0,0 +1,201 @@
+// Copyright 2026 Sonia Keys
+// License MIT: https://opensource.org/licenses/MIT
+
+package graph_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/soniakeys/graph"
+)
+
+func ExampleUndirected_DisjointUnion() {
+	// 0---1  and  0---1  gives  0---1  3---4
+	//  \ /                       \ /
+	//   2                         2
+	var g, h graph.Undirected
+	g.AddEdge(0, 1)
+	g.AddEdge(1, 2)
+	g.AddEdge(2, 0)
+	h.AddEdge(0, 1)
+	fmt.Println(g.DisjointUnion(h).AdjacencyList)
+	// Output:
+	// [[1 2] [0 2] [1 0] [4] [3]]
+}
+
+func ExampleUndirected_Join() {
+	// edgeless graphs of 2 and 3 nodes join to form K2,3
+	g := graph.Undirected{make(graph.AdjacencyList, 2)}
+	h := graph.Undirected{make(graph.AdjacencyList, 3)}
+	j := g.Join(h)
+	for fr, to := range j.AdjacencyList {
+		fmt.Println(fr, to)
+	}
+	// Output:
+	// 0 [2 3 4]
+	// 1 [2 3 4]
+	// 2 [0 1]
+	// 3 [0 1]
+	// 4 [0 1]
+}
+
+func ExampleUndirected_CartesianProduct() {
+	// path 0---1 and path 0---1---2 form the grid
+	//
+	//   0---1---2
+	//   |   |   |
+	//   3---4---5
+	var g, h graph.Undirected
+	g.AddEdge(0, 1)
+	h.AddEdge(0, 1)
+	h.AddEdge(1, 2)
+	p := g.CartesianProduct(h)
+	for fr, to := range p.AdjacencyList {
+		fmt.Println(fr, to)
+	}
+	// Output:
+	// 0 [3 1]
+	// 1 [4 0 2]
+	// 2 [5 1]
+	// 3 [0 4]
+	// 4 [1 3 5]
+	// 5 [2 4]
+}
+
+func ExampleUndirected_TensorProduct() {
+	var g, h graph.Undirected
+	g.AddEdge(0, 1)
+	h.AddEdge(0, 1)
+	h.AddEdge(1, 2)
+	p := g.TensorProduct(h)
+	for fr, to := range p.AdjacencyList {
+		fmt.Println(fr, to)
+	}
+	// Output:
+	// 0 [4]
+	// 1 [3 5]
+	// 2 [4]
+	// 3 [1]
+	// 4 [0 2]
+	// 5 [1]
+}
+
+func ExampleUndirected_StrongProduct() {
+	var g, h graph.Undirected
+	g.AddEdge(0, 1)
+	h.AddEdge(0, 1)
+	p := g.StrongProduct(h)
+	// strong product of two K2s is K4
+	fmt.Println(p.AdjacencyList)
+	// Output:
+	// [[2 1 3] [3 0 2] [0 3 1] [1 2 0]]
+}
+
+func ExampleUndirected_Complement() {
+	// 0---1      0   1
+	//     |      |\ /
+	//     |  ->  | X
+	//     |      |/ \
+	// 3   2      3---2
+	g := graph.Undirected{make(graph.AdjacencyList, 4)}
+	g.AddEdge(0, 1)
+	g.AddEdge(1, 2)
+	fmt.Println(g.Complement().AdjacencyList)
+	// Output:
+	// [[2 3] [3] [0 3] [0 1 2]]
+}
+
+func ExampleDirected_Complement() {
+	g := graph.Directed{graph.AdjacencyList{
+		0: {1},
+		1: {0, 2},
+		2: {},
+	}}
+	fmt.Println(g.Complement().AdjacencyList)
+	// Output:
+	// [[2] [] [0 1]]
+}
+
+func ExampleUndirected_LineGraph() {
+	//     0          edges:       0
+	//    / \         0: 0-1      / \
+	//   1---2        1: 0-2     2---1
+	//       |        2: 1-2      \ /
+	//       3        3: 2-3       3
+	var g graph.Undirected
+	g.AddEdge(0, 1)
+	g.AddEdge(0, 2)
+	g.AddEdge(1, 2)
+	g.AddEdge(2, 3)
+	l, edges, arcNode := g.LineGraph()
+	fmt.Println(edges)
+	fmt.Println(arcNode)
+	for fr, to := range l.AdjacencyList {
+		fmt.Println(fr, to)
+	}
+	// Output:
+	// [{0 1} {0 2} {1 2} {2 3}]
+	// [[0 1] [0 2] [1 2 3] [3]]
+	// 0 [1 2]
+	// 1 [0 2 3]
+	// 2 [0 1 3]
+	// 3 [1 2]
+}
+
+func ExampleDirected_Power() {
+	// 0->1->2->3
+	g := graph.Directed{graph.AdjacencyList{
+		0: {1},
+		1: {2},
+		2: {3},
+		3: {},
+	}}
+	fmt.Println(g.Power(2).AdjacencyList)
+	// Output:
+	// [[1 2] [2 3] [3] []]
+}
+
+func TestUndirectedAlgebra(t *testing.T) {
+	r := rand.New(rand.NewSource(35))
+	g := graph.GnmUndirected(15, 25, r)
+	h := graph.GnmUndirected(10, 12, r)
+	for _, p := range []graph.Undirected{
+		g.DisjointUnion(h),
+		g.Join(h),
+		g.CartesianProduct(h),
+		g.TensorProduct(h),
+		g.StrongProduct(h),
+		g.Complement(),
+		g.Power(3),
+	} {
+		if u, _, _ := p.IsUndirected(); !u {
+			t.Fatal("result not undirected")
+		}
+	}
+	// edge counts
+	m, mh := g.Size(), h.Size()
+	n, nh := g.Order(), h.Order()
+	if s := g.CartesianProduct(h).Size(); s != n*mh+nh*m {
+		t.Fatal("Cartesian product size", s)
+	}
+	if s := g.TensorProduct(h).Size(); s != 2*m*mh {
+		t.Fatal("tensor product size", s)
+	}
+	if s := g.Complement().Size(); s != n*(n-1)/2-m {
+		t.Fatal("complement size", s)
+	}
+	l, edges, _ := g.LineGraph()
+	if len(edges) != m {
+		t.Fatal("line graph order", len(edges))
+	}
+	// sum of C(deg, 2)
+	want := 0
+	for _, to := range g.AdjacencyList {
+		want += len(to) * (len(to) - 1) / 2
+	}
+	if s := l.Size(); s != want {
+		t.Fatal("line graph size", s, "want", want)
+	}
+}
@@ -5,6 +5,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"math"
 	"runtime"
@@ -13,9 +14,19 @@ import (
 	"github.com/soniakeys/graph"
 )
 
+var (
+	grFile = flag.String("gr", "", "DIMACS .gr road network to time")
+	coFile = flag.String("co", "", "DIMACS .co coordinates for -gr network")
+)
+
 func main() {
+	flag.Parse()
 	fmt.Println("Anecdotal timings")
 	fmt.Println(runtime.GOOS, runtime.GOARCH)
+	if *grFile != "" {
+		road(*grFile, *coFile)
+		return
+	}
 	random()
 	prop()
 	trav()
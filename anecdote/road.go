@@ -0,0 +1,114 @@
+// Copyright 2026 Sonia Keys
+// License MIT: http://opensource.org/licenses/MIT
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/soniakeys/graph"
+	"github.com/soniakeys/graph/dimacs"
+)
+
+// road runs timings on a road network from the 9th DIMACS implementation
+// challenge, http://www.dis.uniroma1.it/challenge9/download.shtml.
+//
+// Argument gr is a .gr file of arc weights, co is an optional .co file of
+// node coordinates.  With coordinates, AStar searches are timed as well.
+func road(gr, co string) {
+	fmt.Println("\nDIMACS road network")
+	fmt.Println("Method                 Graph                                          Time")
+	tag := filepath.Base(gr)
+	t := time.Now()
+	g, wt := readGr(gr)
+	d := time.Now().Sub(t)
+	tag += " " + h(g.Order()) + " nds"
+	fmt.Printf("%-22s %-38s %12s\n", "Read .gr", tag, d)
+	w := func(l graph.LI) float64 { return wt[l] }
+
+	// Dijkstra all paths, and pick the farthest node as the end node
+	// for single path searches.
+	t = time.Now()
+	_, dist, _ := g.Dijkstra(0, -1, w)
+	d = time.Now().Sub(t)
+	fmt.Printf("%-22s %-38s %12s\n", "Dijkstra all paths", tag, d)
+	var end graph.NI
+	for n, dn := range dist {
+		if !math.IsInf(dn, 1) && dn > dist[end] {
+			end = graph.NI(n)
+		}
+	}
+
+	t = time.Now()
+	g.Dijkstra(0, end, w)
+	d = time.Now().Sub(t)
+	fmt.Printf("%-22s %-38s %12s\n", "Dijkstra single path", tag, d)
+
+	if co == "" {
+		return
+	}
+	t = time.Now()
+	pos := readCo(co)
+	d = time.Now().Sub(t)
+	fmt.Printf("%-22s %-38s %12s\n", "Read .co", tag, d)
+	if len(pos) != g.Order() {
+		log.Fatal(co, " does not match ", gr)
+	}
+	// Straight line distance between coordinates is not in the units of
+	// arc weights.  Scaling it by the minimum ratio of arc weight to
+	// straight line arc length gives an admissible heuristic.
+	euc := func(n1, n2 graph.NI) float64 {
+		p1, p2 := &pos[n1], &pos[n2]
+		return math.Hypot(p2.X-p1.X, p2.Y-p1.Y)
+	}
+	scale := math.Inf(1)
+	for fr, to := range g.LabeledAdjacencyList {
+		for _, to := range to {
+			if e := euc(graph.NI(fr), to.To); e > 0 {
+				scale = math.Min(scale, wt[to.Label]/e)
+			}
+		}
+	}
+	hf := func(n graph.NI) float64 { return scale * euc(n, end) }
+
+	t = time.Now()
+	g.AStarA(w, 0, end, hf)
+	d = time.Now().Sub(t)
+	fmt.Printf("%-22s %-38s %12s\n", "AStarA", tag, d)
+
+	t = time.Now()
+	g.AStarM(w, 0, end, hf)
+	d = time.Now().Sub(t)
+	fmt.Printf("%-22s %-38s %12s\n", "AStarM", tag, d)
+}
+
+func readGr(fn string) (graph.LabeledDirected, []float64) {
+	f, err := os.Open(fn)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+	g, wt, err := dimacs.ReadSP(f)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return g, wt
+}
+
+func readCo(fn string) []struct{ X, Y float64 } {
+	f, err := os.Open(fn)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+	pos, err := dimacs.ReadCo(f)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return pos
+}
@@ -0,0 +1,478 @@
+// Copyright 2026 Sonia Keys
+// License MIT: https://opensource.org/licenses/MIT
+
+package graph
+
+// binary.go has WriteTo and ReadFrom methods implementing a compact binary
+// encoding for AdjacencyList, LabeledAdjacencyList, and FromList.
+//
+// The encoding is:
+//
+//  magic    4 bytes, "GRPH"
+//  version  1 byte, currently 1
+//  kind     1 byte, 'A' AdjacencyList, 'L' LabeledAdjacencyList, 'F' FromList
+//  order    uvarint, number of nodes
+//  body     depends on kind, see below
+//  checksum 4 bytes, big endian CRC-32 (Castagnoli) of all preceding bytes
+//
+// AdjacencyList body, for each node n:
+//
+//  uvarint  number of arcs from n
+//  varints  to nodes, each as a difference from the previous to node.
+//           The first to node is a difference from n itself.
+//
+// LabeledAdjacencyList body is the same, except each to node is followed by
+// a varint label, coded as a difference from the previous label encoded.
+// (The first label of the graph is a difference from 0.)
+//
+// FromList body:
+//
+//  for each node n:
+//    varint   From, as a difference from the From of node n-1.
+//             (The From of node 0 is a difference from -1.)
+//    uvarint  Len
+//  uvarint  MaxLen
+//  uvarint  0 if Leaves is not allocated to len(Paths),
+//           otherwise 1 + the number of leaves
+//  uvarint  leaf nodes, each as a difference from the previous leaf.
+//           (The first leaf is a difference from 0.)
+//
+// Delta coding keeps numbers small for typical graphs where arc lists are
+// sorted or otherwise clustered, so that most varints take a single byte.
+// Arc order is preserved exactly though; arc lists do not need to be sorted.
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math"
+
+	"github.com/soniakeys/bits"
+)
+
+const (
+	binMagic   = "GRPH"
+	binVersion = 1
+
+	binAdjacencyList        = 'A'
+	binLabeledAdjacencyList = 'L'
+	binFromList             = 'F'
+)
+
+var binTable = crc32.MakeTable(crc32.Castagnoli)
+
+// binWriter writes varints, accumulating a byte count and checksum.
+// The first error encountered is retained and further writes are ignored.
+type binWriter struct {
+	w   *bufio.Writer
+	crc uint32
+	n   int64
+	err error
+	buf [binary.MaxVarintLen64]byte
+}
+
+func newBinWriter(w io.Writer, kind byte, order int) *binWriter {
+	b := &binWriter{w: bufio.NewWriter(w)}
+	b.write([]byte(binMagic))
+	b.write([]byte{binVersion, kind})
+	b.uvarint(uint64(order))
+	return b
+}
+
+func (b *binWriter) write(p []byte) {
+	if b.err != nil {
+		return
+	}
+	b.crc = crc32.Update(b.crc, binTable, p)
+	m, err := b.w.Write(p)
+	b.n += int64(m)
+	b.err = err
+}
+
+func (b *binWriter) uvarint(x uint64) {
+	b.write(b.buf[:binary.PutUvarint(b.buf[:], x)])
+}
+
+func (b *binWriter) varint(x int64) {
+	b.write(b.buf[:binary.PutVarint(b.buf[:], x)])
+}
+
+// finish writes the checksum and flushes.
+func (b *binWriter) finish() (int64, error) {
+	if b.err != nil {
+		return b.n, b.err
+	}
+	var c [4]byte
+	binary.BigEndian.PutUint32(c[:], b.crc)
+	m, err := b.w.Write(c[:])
+	b.n += int64(m)
+	if err != nil {
+		return b.n, err
+	}
+	return b.n, b.w.Flush()
+}
+
+// binReader reads varints, accumulating a byte count and checksum.
+//
+// Bytes are buffered in p until the checksum is updated with them.
+type binReader struct {
+	r   io.ByteReader
+	crc uint32
+	n   int64
+	p   []byte
+}
+
+func newBinReader(r io.Reader) *binReader {
+	br, ok := r.(io.ByteReader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	return &binReader{r: br, p: make([]byte, 0, 4096)}
+}
+
+// ReadByte implements io.ByteReader so that binReader can be passed to
+// binary.ReadUvarint and binary.ReadVarint.
+func (b *binReader) ReadByte() (byte, error) {
+	c, err := b.r.ReadByte()
+	if err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return 0, err
+	}
+	b.n++
+	if len(b.p) == cap(b.p) {
+		b.crc = crc32.Update(b.crc, binTable, b.p)
+		b.p = b.p[:0]
+	}
+	b.p = append(b.p, c)
+	return c, nil
+}
+
+func (b *binReader) uvarint() (uint64, error) {
+	return binary.ReadUvarint(b)
+}
+
+func (b *binReader) varint() (int64, error) {
+	return binary.ReadVarint(b)
+}
+
+// header reads and validates the header, returning the order of the graph.
+func (b *binReader) header(kind byte) (int, error) {
+	var h [len(binMagic) + 2]byte
+	for i := range h {
+		c, err := b.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		h[i] = c
+	}
+	switch {
+	case string(h[:len(binMagic)]) != binMagic:
+		return 0, errors.New("not a binary graph encoding")
+	case h[len(binMagic)] != binVersion:
+		return 0, fmt.Errorf("unsupported encoding version %d",
+			h[len(binMagic)])
+	case h[len(binMagic)+1] != kind:
+		return 0, fmt.Errorf("encoded graph kind %q, expected %q",
+			h[len(binMagic)+1], kind)
+	}
+	return b.count()
+}
+
+// binAllocMax limits preallocation from counts read from an encoding.
+//
+// Counts are read before the data they describe, and before the checksum
+// can be verified.  A corrupt or hostile count must not trigger a huge
+// allocation, so slices start no larger than this and grow with append as
+// data is actually read.
+const binAllocMax = 1024
+
+// allocHint returns c bounded by binAllocMax.
+func allocHint(c int) int {
+	if c > binAllocMax {
+		return binAllocMax
+	}
+	return c
+}
+
+// count reads a uvarint that must be a valid slice length of NIs.
+func (b *binReader) count() (int, error) {
+	c, err := b.uvarint()
+	if err != nil {
+		return 0, err
+	}
+	if c > math.MaxInt32 {
+		return 0, fmt.Errorf("count %d out of range", c)
+	}
+	return int(c), nil
+}
+
+// ni reads a varint delta, returning prev + delta.
+func (b *binReader) ni(prev int64) (int64, error) {
+	d, err := b.varint()
+	if err != nil {
+		return 0, err
+	}
+	x := prev + d
+	if x < math.MinInt32 || x > math.MaxInt32 {
+		return 0, fmt.Errorf("value %d out of range", x)
+	}
+	return x, nil
+}
+
+// finish reads and verifies the checksum.
+func (b *binReader) finish() (int64, error) {
+	crc := crc32.Update(b.crc, binTable, b.p)
+	var c [4]byte
+	for i := range c {
+		x, err := b.r.ReadByte()
+		if err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return b.n, err
+		}
+		b.n++
+		c[i] = x
+	}
+	if binary.BigEndian.Uint32(c[:]) != crc {
+		return b.n, errors.New("checksum mismatch")
+	}
+	return b.n, nil
+}
+
+// WriteTo writes a compact binary encoding of g to w.
+//
+// WriteTo implements io.WriterTo.  It returns the number of bytes written
+// and any error encountered.
+//
+// The encoding is versioned and includes a checksum.  Use ReadFrom to decode.
+func (g AdjacencyList) WriteTo(w io.Writer) (n int64, err error) {
+	b := newBinWriter(w, binAdjacencyList, len(g))
+	for fr, to := range g {
+		b.uvarint(uint64(len(to)))
+		prev := int64(fr)
+		for _, to := range to {
+			b.varint(int64(to) - prev)
+			prev = int64(to)
+		}
+	}
+	return b.finish()
+}
+
+// ReadFrom decodes a graph encoded with AdjacencyList.WriteTo, replacing
+// the contents of *g.
+//
+// ReadFrom implements io.ReaderFrom.  It returns the number of bytes read
+// and any error encountered.
+//
+// The checksum is verified and the decoded graph is validated with BoundsOk.
+// If either fails, or on any other error, *g is left unchanged.
+//
+// If r does not implement io.ByteReader, it is wrapped in a bufio.Reader,
+// which may read beyond the end of the encoded graph.
+func (g *AdjacencyList) ReadFrom(r io.Reader) (n int64, err error) {
+	b := newBinReader(r)
+	order, err := b.header(binAdjacencyList)
+	if err != nil {
+		return b.n, err
+	}
+	a := make(AdjacencyList, 0, allocHint(order))
+	for fr := 0; fr < order; fr++ {
+		d, err := b.count()
+		if err != nil {
+			return b.n, err
+		}
+		if d == 0 {
+			a = append(a, nil)
+			continue
+		}
+		to := make([]NI, 0, allocHint(d))
+		prev := int64(fr)
+		for i := 0; i < d; i++ {
+			if prev, err = b.ni(prev); err != nil {
+				return b.n, err
+			}
+			to = append(to, NI(prev))
+		}
+		a = append(a, to)
+	}
+	if n, err = b.finish(); err != nil {
+		return
+	}
+	if ok, fr, to := a.BoundsOk(); !ok {
+		return n, fmt.Errorf("arc %d->%d out of bounds", fr, to)
+	}
+	*g = a
+	return
+}
+
+// WriteTo writes a compact binary encoding of g to w.
+//
+// WriteTo implements io.WriterTo.  It returns the number of bytes written
+// and any error encountered.
+//
+// The encoding is versioned and includes a checksum.  Use ReadFrom to decode.
+func (g LabeledAdjacencyList) WriteTo(w io.Writer) (n int64, err error) {
+	b := newBinWriter(w, binLabeledAdjacencyList, len(g))
+	var prevLabel int64
+	for fr, to := range g {
+		b.uvarint(uint64(len(to)))
+		prev := int64(fr)
+		for _, to := range to {
+			b.varint(int64(to.To) - prev)
+			b.varint(int64(to.Label) - prevLabel)
+			prev = int64(to.To)
+			prevLabel = int64(to.Label)
+		}
+	}
+	return b.finish()
+}
+
+// ReadFrom decodes a graph encoded with LabeledAdjacencyList.WriteTo,
+// replacing the contents of *g.
+//
+// ReadFrom implements io.ReaderFrom.  It returns the number of bytes read
+// and any error encountered.
+//
+// The checksum is verified and the decoded graph is validated with BoundsOk.
+// If either fails, or on any other error, *g is left unchanged.
+//
+// If r does not implement io.ByteReader, it is wrapped in a bufio.Reader,
+// which may read beyond the end of the encoded graph.
+func (g *LabeledAdjacencyList) ReadFrom(r io.Reader) (n int64, err error) {
+	b := newBinReader(r)
+	order, err := b.header(binLabeledAdjacencyList)
+	if err != nil {
+		return b.n, err
+	}
+	a := make(LabeledAdjacencyList, 0, allocHint(order))
+	var prevLabel int64
+	for fr := 0; fr < order; fr++ {
+		d, err := b.count()
+		if err != nil {
+			return b.n, err
+		}
+		if d == 0 {
+			a = append(a, nil)
+			continue
+		}
+		to := make([]Half, 0, allocHint(d))
+		prev := int64(fr)
+		for i := 0; i < d; i++ {
+			if prev, err = b.ni(prev); err != nil {
+				return b.n, err
+			}
+			if prevLabel, err = b.ni(prevLabel); err != nil {
+				return b.n, err
+			}
+			to = append(to, Half{NI(prev), LI(prevLabel)})
+		}
+		a = append(a, to)
+	}
+	if n, err = b.finish(); err != nil {
+		return
+	}
+	if ok, fr, to := a.BoundsOk(); !ok {
+		return n, fmt.Errorf("arc %d->%d out of bounds", fr, to.To)
+	}
+	*g = a
+	return
+}
+
+// WriteTo writes a compact binary encoding of f to w.
+//
+// All members of f are encoded:  From and Len of each PathEnd, MaxLen,
+// and Leaves.  Leaves is encoded only if it is allocated to len(f.Paths).
+//
+// WriteTo implements io.WriterTo.  It returns the number of bytes written
+// and any error encountered.
+//
+// The encoding is versioned and includes a checksum.  Use ReadFrom to decode.
+func (f FromList) WriteTo(w io.Writer) (n int64, err error) {
+	b := newBinWriter(w, binFromList, len(f.Paths))
+	prev := int64(-1)
+	for _, e := range f.Paths {
+		b.varint(int64(e.From) - prev)
+		b.uvarint(uint64(e.Len))
+		prev = int64(e.From)
+	}
+	b.uvarint(uint64(f.MaxLen))
+	if f.Leaves.Num != len(f.Paths) {
+		b.uvarint(0)
+		return b.finish()
+	}
+	b.uvarint(uint64(f.Leaves.OnesCount()) + 1)
+	prevLeaf := 0
+	f.Leaves.IterateOnes(func(n int) bool {
+		b.uvarint(uint64(n - prevLeaf))
+		prevLeaf = n
+		return true
+	})
+	return b.finish()
+}
+
+// ReadFrom decodes a FromList encoded with FromList.WriteTo, replacing
+// the contents of *f.
+//
+// ReadFrom implements io.ReaderFrom.  It returns the number of bytes read
+// and any error encountered.
+//
+// The checksum is verified and the decoded FromList is validated with
+// BoundsOk.  If either fails, or on any other error, *f is left unchanged.
+//
+// If r does not implement io.ByteReader, it is wrapped in a bufio.Reader,
+// which may read beyond the end of the encoded FromList.
+func (f *FromList) ReadFrom(r io.Reader) (n int64, err error) {
+	b := newBinReader(r)
+	order, err := b.header(binFromList)
+	if err != nil {
+		return b.n, err
+	}
+	// Paths grows as data is read rather than being allocated from order.
+	d := FromList{Paths: make([]PathEnd, 0, allocHint(order))}
+	prev := int64(-1)
+	for i := 0; i < order; i++ {
+		if prev, err = b.ni(prev); err != nil {
+			return b.n, err
+		}
+		l, err := b.count()
+		if err != nil {
+			return b.n, err
+		}
+		d.Paths = append(d.Paths, PathEnd{From: NI(prev), Len: l})
+	}
+	if d.MaxLen, err = b.count(); err != nil {
+		return b.n, err
+	}
+	nLeaves, err := b.count()
+	if err != nil {
+		return b.n, err
+	}
+	if nLeaves > 0 {
+		d.Leaves = bits.New(order)
+		leaf := 0
+		for i := 1; i < nLeaves; i++ {
+			x, err := b.count()
+			if err != nil {
+				return b.n, err
+			}
+			if leaf += x; leaf >= order {
+				return b.n, fmt.Errorf("leaf %d out of bounds", leaf)
+			}
+			d.Leaves.SetBit(leaf, 1)
+		}
+	}
+	if n, err = b.finish(); err != nil {
+		return
+	}
+	if ok, x := d.BoundsOk(); !ok {
+		return n, fmt.Errorf("from value of node %d out of bounds", x)
+	}
+	*f = d
+	return
+}
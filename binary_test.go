@@ -0,0 +1,216 @@
+// Copyright 2026 Sonia Keys
+// License MIT: https://opensource.org/licenses/MIT
+
+package graph_test
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/soniakeys/graph"
+)
+
+func ExampleAdjacencyList_WriteTo() {
+	//   0
+	//  / \
+	// 1-->2
+	// ^   |
+	// |   v
+	// \---3
+	g := graph.AdjacencyList{
+		0: {1, 2},
+		1: {2},
+		2: {3},
+		3: {1},
+	}
+	var b bytes.Buffer
+	n, err := g.WriteTo(&b)
+	fmt.Println(n, err)
+
+	var d graph.AdjacencyList
+	n, err = d.ReadFrom(&b)
+	fmt.Println(n, err)
+	for fr, to := range d {
+		fmt.Println(fr, to)
+	}
+	// Output:
+	// 20 <nil>
+	// 20 <nil>
+	// 0 [1 2]
+	// 1 [2]
+	// 2 [3]
+	// 3 [1]
+}
+
+func ExampleLabeledAdjacencyList_WriteTo() {
+	//        0
+	// (10) /   \ (20)
+	//     1-----2
+	//      (30)
+	g := graph.LabeledAdjacencyList{
+		0: {{To: 1, Label: 10}, {To: 2, Label: 20}},
+		1: {{To: 2, Label: 30}},
+		2: nil,
+	}
+	var b bytes.Buffer
+	g.WriteTo(&b)
+
+	var d graph.LabeledAdjacencyList
+	_, err := d.ReadFrom(&b)
+	fmt.Println(err)
+	for fr, to := range d {
+		fmt.Println(fr, to)
+	}
+	// Output:
+	// <nil>
+	// 0 [{1 10} {2 20}]
+	// 1 [{2 30}]
+	// 2 []
+}
+
+func ExampleFromList_WriteTo() {
+	//   0
+	//  / \
+	// 1   2
+	//    /
+	//   3
+	f := graph.FromList{Paths: []graph.PathEnd{
+		0: {From: -1, Len: 1},
+		1: {From: 0, Len: 2},
+		2: {From: 0, Len: 2},
+		3: {From: 2, Len: 3},
+	}, MaxLen: 3}
+	f.RecalcLeaves()
+	var b bytes.Buffer
+	f.WriteTo(&b)
+
+	var d graph.FromList
+	_, err := d.ReadFrom(&b)
+	fmt.Println(err)
+	fmt.Println(d.Paths)
+	fmt.Println(d.MaxLen, d.Leaves.Slice())
+	// Output:
+	// <nil>
+	// [{-1 1} {0 2} {0 2} {2 3}]
+	// 3 [1 3]
+}
+
+func TestBinaryRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(26))
+	g, _, wt, err := graph.LabeledEuclidean(500, 3000, 1, 10, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b bytes.Buffer
+	if _, err := g.WriteTo(&b); err != nil {
+		t.Fatal(err)
+	}
+	var l graph.LabeledAdjacencyList
+	if _, err := l.ReadFrom(&b); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(l, g.LabeledAdjacencyList) {
+		t.Fatal("labeled round trip mismatch")
+	}
+
+	u := g.Unlabeled()
+	b.Reset()
+	u.WriteTo(&b)
+	var a graph.AdjacencyList
+	if _, err := a.ReadFrom(&b); err != nil {
+		t.Fatal(err)
+	}
+	if len(a) != len(u.AdjacencyList) {
+		t.Fatal("unlabeled round trip order mismatch")
+	}
+	for fr, to := range u.AdjacencyList {
+		if len(to) == 0 && len(a[fr]) == 0 {
+			continue // nil and empty are equivalent
+		}
+		if !reflect.DeepEqual(a[fr], to) {
+			t.Fatal("unlabeled round trip mismatch at node", fr)
+		}
+	}
+
+	f, _, _ := g.Dijkstra(0, -1, func(l graph.LI) float64 { return wt[l] })
+	b.Reset()
+	f.WriteTo(&b)
+	var f2 graph.FromList
+	if _, err := f2.ReadFrom(&b); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(f2, f) {
+		t.Fatal("FromList round trip mismatch")
+	}
+}
+
+func TestBinaryErrors(t *testing.T) {
+	g := graph.AdjacencyList{
+		0: {1, 2},
+		1: {2},
+		2: {0},
+	}
+	var b bytes.Buffer
+	g.WriteTo(&b)
+	enc := b.Bytes()
+
+	// corrupt an arc
+	bad := append([]byte{}, enc...)
+	bad[8]++
+	var d graph.AdjacencyList
+	if _, err := d.ReadFrom(bytes.NewReader(bad)); err == nil {
+		t.Fatal("corrupt data not detected")
+	}
+	if d != nil {
+		t.Fatal("receiver modified on error")
+	}
+	// truncated
+	if _, err := d.ReadFrom(bytes.NewReader(enc[:len(enc)-1])); err == nil {
+		t.Fatal("truncated data not detected")
+	}
+	// wrong kind
+	var l graph.LabeledAdjacencyList
+	if _, err := l.ReadFrom(bytes.NewReader(enc)); err == nil {
+		t.Fatal("wrong kind not detected")
+	}
+	// valid checksum but arc out of bounds
+	b.Reset()
+	graph.AdjacencyList{0: {5}}.WriteTo(&b)
+	if _, err := d.ReadFrom(&b); err == nil {
+		t.Fatal("out of bounds arc not detected")
+	}
+	// hostile orders and degrees, without data to back them up, must fail
+	// without attempting large allocations.
+	hostile := [][]byte{
+		{'G', 'R', 'P', 'H', 1, 'A', 0xff, 0xff, 0xff, 0xff, 7},
+		{'G', 'R', 'P', 'H', 1, 'L', 0xff, 0xff, 0xff, 0xff, 7},
+		{'G', 'R', 'P', 'H', 1, 'F', 0xff, 0xff, 0xff, 0xff, 7},
+		{'G', 'R', 'P', 'H', 1, 'A', 1, 0xff, 0xff, 0xff, 0xff, 7},
+		{'G', 'R', 'P', 'H', 1, 'L', 1, 0xff, 0xff, 0xff, 0xff, 7},
+	}
+	for _, h := range hostile {
+		var err error
+		switch h[5] {
+		case 'A':
+			_, err = d.ReadFrom(bytes.NewReader(h))
+		case 'L':
+			_, err = l.ReadFrom(bytes.NewReader(h))
+		case 'F':
+			var f graph.FromList
+			_, err = f.ReadFrom(bytes.NewReader(h))
+		}
+		if err == nil {
+			t.Fatalf("hostile header % x not detected", h)
+		}
+	}
+	// truncated and corrupt headers
+	for _, h := range []string{"", "GRP", "GRPH", "GRPH\x01", "GRPH\x01A",
+		"GRPH\x01A\x80", "GRPX\x01A\x00", "GRPH\x02A\x00"} {
+		if _, err := d.ReadFrom(bytes.NewReader([]byte(h))); err == nil {
+			t.Fatalf("bad header %q not detected", h)
+		}
+	}
+}
@@ -0,0 +1,412 @@
+// Copyright 2026 Sonia Keys
+// License MIT: https://opensource.org/licenses/MIT
+
+package graph
+
+import (
+	"container/heap"
+
+	"github.com/soniakeys/bits"
+)
+
+// CSR represents a graph in compressed sparse row form.
+//
+// Arcs from node n are To[Offsets[n]:Offsets[n+1]], so Offsets has length
+// one more than the order of the graph.  Compared to an AdjacencyList, CSR
+// saves a slice header per node and keeps all arcs in a single contiguous
+// array.  It is intended as a static representation.  Build a graph as an
+// AdjacencyList or LabeledAdjacencyList, then convert it.
+//
+// Labels, if non-nil, is parallel to To and holds arc labels.  If Labels is
+// nil, the label of an arc is its index in To.  This allows arc weights or
+// other arc data to be kept in slices parallel to To.
+//
+// A CSR can represent either a directed or undirected graph.  Methods
+// document which they assume.
+type CSR struct {
+	Offsets []int
+	To      []NI
+	Labels  []LI
+}
+
+// CSR converts an AdjacencyList to compressed sparse row form.
+//
+// The Labels member of the result is nil.
+func (g AdjacencyList) CSR() CSR {
+	c := CSR{Offsets: make([]int, len(g)+1)}
+	for n, to := range g {
+		c.Offsets[n+1] = c.Offsets[n] + len(to)
+	}
+	c.To = make([]NI, 0, c.Offsets[len(g)])
+	for _, to := range g {
+		c.To = append(c.To, to...)
+	}
+	return c
+}
+
+// CSR converts a LabeledAdjacencyList to compressed sparse row form.
+func (g LabeledAdjacencyList) CSR() CSR {
+	c := CSR{Offsets: make([]int, len(g)+1)}
+	for n, to := range g {
+		c.Offsets[n+1] = c.Offsets[n] + len(to)
+	}
+	ma := c.Offsets[len(g)]
+	c.To = make([]NI, 0, ma)
+	c.Labels = make([]LI, 0, ma)
+	for _, to := range g {
+		for _, h := range to {
+			c.To = append(c.To, h.To)
+			c.Labels = append(c.Labels, h.Label)
+		}
+	}
+	return c
+}
+
+// Order returns the number of nodes of c.
+func (c CSR) Order() int {
+	if len(c.Offsets) == 0 {
+		return 0
+	}
+	return len(c.Offsets) - 1
+}
+
+// ArcSize returns the number of arcs of c.
+func (c CSR) ArcSize() int {
+	return len(c.To)
+}
+
+// Label returns the label of the arc at index x of To.
+func (c CSR) Label(x int) LI {
+	if c.Labels == nil {
+		return LI(x)
+	}
+	return c.Labels[x]
+}
+
+// AdjacencyList converts c to an AdjacencyList.
+//
+// The arc lists of the result share a single newly allocated array but
+// have capacities limited so that appending to one arc list does not
+// affect another.
+func (c CSR) AdjacencyList() AdjacencyList {
+	g := make(AdjacencyList, c.Order())
+	to := append([]NI{}, c.To...)
+	for n := range g {
+		lo, hi := c.Offsets[n], c.Offsets[n+1]
+		if lo < hi {
+			g[n] = to[lo:hi:hi]
+		}
+	}
+	return g
+}
+
+// LabeledAdjacencyList converts c to a LabeledAdjacencyList.
+//
+// If c.Labels is nil, arc labels of the result are indexes into c.To.
+// Arc lists of the result share a single newly allocated array but have
+// capacities limited so that appending to one arc list does not affect
+// another.
+func (c CSR) LabeledAdjacencyList() LabeledAdjacencyList {
+	g := make(LabeledAdjacencyList, c.Order())
+	h := make([]Half, len(c.To))
+	for x, to := range c.To {
+		h[x] = Half{to, c.Label(x)}
+	}
+	for n := range g {
+		lo, hi := c.Offsets[n], c.Offsets[n+1]
+		if lo < hi {
+			g[n] = h[lo:hi:hi]
+		}
+	}
+	return g
+}
+
+// BreadthFirst traverses a directed or undirected graph in breadth first order.
+//
+// The method is equivalent to AdjacencyList.BreadthFirst, supporting the
+// same options and producing identical results.
+func (c CSR) BreadthFirst(start NI, opt ...TraverseOption) {
+	cf := &config{start: start}
+	for _, o := range opt {
+		o(cf)
+	}
+	f := cf.fromList
+	switch {
+	case f == nil:
+		e := NewFromList(c.Order())
+		f = &e
+	case f.Paths == nil:
+		*f = NewFromList(c.Order())
+	}
+	rp := f.Paths
+	// the frontier consists of nodes all at the same level
+	frontier := []NI{cf.start}
+	level := 1
+	// assign path when node is put on frontier
+	rp[cf.start] = PathEnd{Len: level, From: -1}
+	for {
+		f.MaxLen = level
+		level++
+		var next []NI
+		visit := func(n NI) bool {
+			// visit nodes as they come off frontier
+			if cf.nodeVisitor != nil {
+				cf.nodeVisitor(n)
+			}
+			if cf.okNodeVisitor != nil {
+				if !cf.okNodeVisitor(n) {
+					return false
+				}
+			}
+			for _, nb := range c.To[c.Offsets[n]:c.Offsets[n+1]] {
+				if rp[nb].Len == 0 {
+					next = append(next, nb)
+					rp[nb] = PathEnd{From: n, Len: level}
+				}
+			}
+			return true
+		}
+		if cf.rand == nil {
+			for _, n := range frontier {
+				if !visit(n) {
+					return
+				}
+			}
+		} else { // take nodes off frontier at random
+			for _, i := range cf.rand.Perm(len(frontier)) {
+				if !visit(frontier[i]) {
+					return
+				}
+			}
+		}
+		if len(next) == 0 {
+			break
+		}
+		frontier = next
+	}
+}
+
+// DepthFirst traverses a directed or undirected graph in depth first order.
+//
+// The method is equivalent to AdjacencyList.DepthFirst, supporting the
+// same options and producing identical results.  Arguments x passed to
+// arc visitors are indexes within the arcs of node n, as with
+// AdjacencyList.  The corresponding index into c.To is c.Offsets[n]+x.
+func (c CSR) DepthFirst(start NI, options ...TraverseOption) {
+	cf := &config{start: start}
+	for _, o := range options {
+		o(cf)
+	}
+	b := cf.visBits
+	if b == nil {
+		n := bits.New(c.Order())
+		b = &n
+	} else if b.Bit(int(cf.start)) != 0 {
+		return
+	}
+	if cf.pathBits != nil {
+		cf.pathBits.ClearAll()
+	}
+	var df func(NI) bool
+	df = func(n NI) bool {
+		b.SetBit(int(n), 1)
+		if cf.pathBits != nil {
+			cf.pathBits.SetBit(int(n), 1)
+		}
+
+		if cf.nodeVisitor != nil {
+			cf.nodeVisitor(n)
+		}
+		if cf.okNodeVisitor != nil {
+			if !cf.okNodeVisitor(n) {
+				return false
+			}
+		}
+
+		to := c.To[c.Offsets[n]:c.Offsets[n+1]]
+		arc := func(x int) bool {
+			if cf.arcVisitor != nil {
+				cf.arcVisitor(n, x)
+			}
+			if cf.okArcVisitor != nil {
+				if !cf.okArcVisitor(n, x) {
+					return false
+				}
+			}
+			if b.Bit(int(to[x])) != 0 {
+				return true
+			}
+			return df(to[x])
+		}
+		if cf.rand == nil {
+			for x := range to {
+				if !arc(x) {
+					return false
+				}
+			}
+		} else {
+			for _, x := range cf.rand.Perm(len(to)) {
+				if !arc(x) {
+					return false
+				}
+			}
+		}
+		if cf.pathBits != nil {
+			cf.pathBits.SetBit(int(n), 0)
+		}
+		return true
+	}
+	df(cf.start)
+}
+
+// Dijkstra finds shortest paths by Dijkstra's algorithm.
+//
+// The method is equivalent to LabeledAdjacencyList.Dijkstra and produces
+// identical results.  Weight function w is called with arc labels as
+// returned by c.Label.
+func (c CSR) Dijkstra(start, end NI, w WeightFunc) (f FromList, dist []float64, reached int) {
+	r := make([]tentResult[float64], c.Order())
+	for i := range r {
+		r[i].nx = NI(i)
+	}
+	f = NewFromList(c.Order())
+	dist = make([]float64, c.Order())
+	current := start
+	rp := f.Paths
+	rp[current] = PathEnd{Len: 1, From: -1} // path length at start is 1 node
+	cr := &r[current]
+	cr.dist = 0    // distance at start is 0.
+	cr.done = true // mark start done.  it skips the heap.
+	nDone := 1     // accumulated for a return value
+	var t tent[float64]
+	for current != end {
+		nextLen := rp[current].Len + 1
+		for x := c.Offsets[current]; x < c.Offsets[current+1]; x++ {
+			nb := c.To[x]
+			hr := &r[nb]
+			if hr.done {
+				continue // skip nodes already done
+			}
+			dist := cr.dist + w(c.Label(x))
+			vl := rp[nb].Len
+			visited := vl > 0
+			if visited {
+				if dist > hr.dist {
+					continue // distance is worse
+				}
+				if dist == hr.dist && nextLen >= vl {
+					continue // distance same, but number of nodes is no better
+				}
+			}
+			// the path through current to this node is shortest so far.
+			// record new path data for this node and update tentative set.
+			hr.dist = dist
+			rp[nb].Len = nextLen
+			rp[nb].From = current
+			if visited {
+				heap.Fix(&t, hr.fx)
+			} else {
+				heap.Push(&t, hr)
+			}
+		}
+		if len(t) == 0 {
+			return f, dist, nDone // no more reachable nodes. AllPaths normal return
+		}
+		// new current is node with smallest tentative distance
+		cr = heap.Pop(&t).(*tentResult[float64])
+		cr.done = true
+		nDone++
+		current = cr.nx
+		dist[current] = cr.dist // store final distance
+	}
+	// normal return for single shortest path search
+	return f, dist, -1
+}
+
+// StronglyConnectedComponents identifies strongly connected components in
+// a directed graph.
+//
+// The method is equivalent to Directed.StronglyConnectedComponents and
+// emits identical components in identical order.
+func (c CSR) StronglyConnectedComponents(emit func([]NI) bool) {
+	// Pearce's algorithm, as in Directed.StronglyConnectedComponents.
+	rindex := make([]int, c.Order())
+	var S []NI
+	index := 1
+	cx := c.Order() - 1
+	var visit func(NI) bool
+	visit = func(v NI) bool {
+		root := true
+		rindex[v] = index
+		index++
+		for _, w := range c.To[c.Offsets[v]:c.Offsets[v+1]] {
+			if rindex[w] == 0 {
+				if !visit(w) {
+					return false
+				}
+			}
+			if rindex[w] < rindex[v] {
+				rindex[v] = rindex[w]
+				root = false
+			}
+		}
+		if !root {
+			S = append(S, v)
+			return true
+		}
+		var scc []NI
+		index--
+		for last := len(S) - 1; last >= 0; last-- {
+			w := S[last]
+			if rindex[v] > rindex[w] {
+				break
+			}
+			S = S[:last]
+			rindex[w] = cx
+			scc = append(scc, w)
+			index--
+		}
+		rindex[v] = cx
+		cx--
+		return emit(append(scc, v))
+	}
+	for v := range rindex {
+		if rindex[v] == 0 && !visit(NI(v)) {
+			break
+		}
+	}
+}
+
+// ConnectedComponentLists returns a function that iterates over connected
+// components of an undirected graph, returning the member list of each.
+//
+// The method is equivalent to Undirected.ConnectedComponentLists and
+// produces identical results.
+func (c CSR) ConnectedComponentLists() func() (nodes []NI, arcSize int) {
+	vg := bits.New(c.Order()) // nodes visited in graph
+	var l []NI                // accumulated node list of current component
+	var ma int                // accumulated arc size of current component
+	var df func(NI)
+	df = func(n NI) {
+		vg.SetBit(int(n), 1)
+		l = append(l, n)
+		to := c.To[c.Offsets[n]:c.Offsets[n+1]]
+		ma += len(to)
+		for _, nb := range to {
+			if vg.Bit(int(nb)) == 0 {
+				df(nb)
+			}
+		}
+	}
+	var n int
+	return func() ([]NI, int) {
+		for ; n < c.Order(); n++ {
+			if vg.Bit(n) == 0 {
+				l, ma = nil, 0
+				df(NI(n))
+				return l, ma
+			}
+		}
+		return nil, 0
+	}
+}
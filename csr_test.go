@@ -0,0 +1,168 @@
+// Copyright 2026 Sonia Keys
+// License MIT: https://opensource.org/licenses/MIT
+
+package graph_test
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/soniakeys/bits"
+	"github.com/soniakeys/graph"
+)
+
+func ExampleAdjacencyList_CSR() {
+	//   0
+	//  / \
+	// 1-->2
+	// ^   |
+	// |   v
+	// \---3
+	g := graph.AdjacencyList{
+		0: {1, 2},
+		1: {2},
+		2: {3},
+		3: {1},
+	}
+	c := g.CSR()
+	fmt.Println(c.Offsets)
+	fmt.Println(c.To)
+	fmt.Println(c.AdjacencyList())
+	// Output:
+	// [0 2 3 4 5]
+	// [1 2 2 3 1]
+	// [[1 2] [2] [3] [1]]
+}
+
+func ExampleCSR_Dijkstra() {
+	//   0
+	//  / \
+	// 1-->2
+	// ^   |
+	// |   v
+	// \---3
+	g := graph.AdjacencyList{
+		0: {1, 2},
+		1: {2},
+		2: {3},
+		3: {1},
+	}
+	c := g.CSR()
+	// Labels is nil, so weights are indexed by position in c.To
+	w := []float64{2, 10, 3, 1, 1}
+	f, dist, _ := c.Dijkstra(0, -1, func(l graph.LI) float64 { return w[l] })
+	fmt.Println(f.PathTo(3, nil), dist[3])
+	// Output:
+	// [0 1 2 3] 6
+}
+
+func ExampleCSR_StronglyConnectedComponents() {
+	//   0
+	//  / \
+	// 1-->2
+	// ^   |
+	// |   v
+	// \---3
+	g := graph.AdjacencyList{
+		0: {1, 2},
+		1: {2},
+		2: {3},
+		3: {1},
+	}
+	g.CSR().StronglyConnectedComponents(func(c []graph.NI) bool {
+		fmt.Println(c)
+		return true
+	})
+	// Output:
+	// [2 3 1]
+	// [0]
+}
+
+func TestCSRIdentical(t *testing.T) {
+	tc := r(300, 1500, 31)
+	g := tc.g.AdjacencyList
+	l := tc.l.LabeledAdjacencyList
+	c := g.CSR()
+	cl := l.CSR()
+	if !reflect.DeepEqual(cl.LabeledAdjacencyList(), l) {
+		t.Fatal("labeled conversion mismatch")
+	}
+	for n, to := range c.AdjacencyList() {
+		if len(to) != len(g[n]) || len(to) > 0 && !reflect.DeepEqual(to, g[n]) {
+			t.Fatal("conversion mismatch at node", n)
+		}
+	}
+
+	// BreadthFirst
+	var f1, f2 graph.FromList
+	var v1, v2 []graph.NI
+	g.BreadthFirst(tc.start, graph.From(&f1),
+		graph.NodeVisitor(func(n graph.NI) { v1 = append(v1, n) }))
+	c.BreadthFirst(tc.start, graph.From(&f2),
+		graph.NodeVisitor(func(n graph.NI) { v2 = append(v2, n) }))
+	if !reflect.DeepEqual(f1, f2) || !reflect.DeepEqual(v1, v2) {
+		t.Fatal("BreadthFirst mismatch")
+	}
+	v1, v2 = nil, nil
+	g.BreadthFirst(tc.start, graph.Rand(rand.New(rand.NewSource(3))),
+		graph.NodeVisitor(func(n graph.NI) { v1 = append(v1, n) }))
+	c.BreadthFirst(tc.start, graph.Rand(rand.New(rand.NewSource(3))),
+		graph.NodeVisitor(func(n graph.NI) { v2 = append(v2, n) }))
+	if !reflect.DeepEqual(v1, v2) {
+		t.Fatal("BreadthFirst Rand mismatch")
+	}
+
+	// DepthFirst
+	type arc struct {
+		n graph.NI
+		x int
+	}
+	var a1, a2 []arc
+	b1, b2 := bits.New(len(g)), bits.New(len(g))
+	g.DepthFirst(tc.start, graph.Visited(&b1),
+		graph.ArcVisitor(func(n graph.NI, x int) { a1 = append(a1, arc{n, x}) }))
+	c.DepthFirst(tc.start, graph.Visited(&b2),
+		graph.ArcVisitor(func(n graph.NI, x int) { a2 = append(a2, arc{n, x}) }))
+	if !reflect.DeepEqual(a1, a2) || !b1.Equal(b2) {
+		t.Fatal("DepthFirst mismatch")
+	}
+
+	// Dijkstra
+	w := func(l graph.LI) float64 { return tc.w[l] }
+	fd1, d1, r1 := l.Dijkstra(tc.start, -1, w)
+	fd2, d2, r2 := cl.Dijkstra(tc.start, -1, w)
+	if !reflect.DeepEqual(fd1, fd2) || !reflect.DeepEqual(d1, d2) || r1 != r2 {
+		t.Fatal("Dijkstra mismatch")
+	}
+
+	// StronglyConnectedComponents
+	var s1, s2 [][]graph.NI
+	tc.g.StronglyConnectedComponents(func(c []graph.NI) bool {
+		s1 = append(s1, append([]graph.NI{}, c...))
+		return true
+	})
+	c.StronglyConnectedComponents(func(c []graph.NI) bool {
+		s2 = append(s2, append([]graph.NI{}, c...))
+		return true
+	})
+	if !reflect.DeepEqual(s1, s2) {
+		t.Fatal("StronglyConnectedComponents mismatch")
+	}
+
+	// ConnectedComponentLists
+	u := graph.GnmUndirected(300, 400, rand.New(rand.NewSource(31)))
+	it1 := u.ConnectedComponentLists()
+	it2 := u.AdjacencyList.CSR().ConnectedComponentLists()
+	for {
+		n1, m1 := it1()
+		n2, m2 := it2()
+		if !reflect.DeepEqual(n1, n2) || m1 != m2 {
+			t.Fatal("ConnectedComponentLists mismatch")
+		}
+		if n1 == nil {
+			break
+		}
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright 2026 Sonia Keys
+// License MIT: https://opensource.org/licenses/MIT
+
+// Package cytoscape writes graphs from package graph in the Cytoscape JSON
+// format read by Cytoscape.js and Cytoscape desktop.
+//
+// The format is the elements JSON described at
+// http://js.cytoscape.org/#notation/elements-json.  Output is an object
+// with members "data", holding a "directed" flag, and "elements", holding
+// arrays of "nodes" and "edges."
+//
+// Like package dot, cytoscape is a separate package from graph.  It imports
+// graph; graph knows nothing of cytoscape.  The scheme for optional arguments
+// is also that of package dot.  The Write and String functions take optional
+// arguments constructed by configuration functions of this package.
+//
+// All nodes of the graph are written, including isolated nodes.  Node IDs
+// are node numbers of the graph package.  Edge IDs are "e" followed by
+// numbers from 0 in the order written.  Edges of undirected graphs are
+// written with the lesser node number as the source.
+package cytoscape
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/soniakeys/graph"
+	"github.com/soniakeys/graph/internal/export"
+)
+
+// AttrVal represents a data field name and value.
+type AttrVal = export.AttrVal
+
+// Config holds options that control the Cytoscape JSON output.
+//
+// Generally you will not set members of a Config struct directly.  There is
+// an option function for each member.
+type Config = export.Config
+
+// Defaults holds a package default Config struct.
+//
+// Defaults is copied as the first configuration step.
+var Defaults = Config{}
+
+// Option is the type of optional arguments to Write and String.
+type Option = export.Option
+
+// EdgeAttr specifies a function to generate a list of edge data fields
+// given the arc label integers of the graph package.
+//
+// Edge data fields are written only for labeled graph types.  Values are
+// written as strings.  Field names id, source, target, label, and weight
+// are reserved.
+func EdgeAttr(f func(graph.LI) []AttrVal) Option { return export.EdgeAttr(f) }
+
+// EdgeLabel specifies a function to generate edge labels given the arc
+// label integers of the graph package.
+//
+// Labels are written as the data field "label," and only for labeled
+// graph types.
+func EdgeLabel(f func(graph.LI) string) Option { return export.EdgeLabel(f) }
+
+// EdgeWeight specifies a weight function for writing edge weights.
+//
+// Weights are written as the numeric data field "weight," and only for
+// labeled graph types.  NaN and infinite weights have no JSON representation
+// and are omitted.
+func EdgeWeight(w graph.WeightFunc) Option { return export.EdgeWeight(w) }
+
+// NodeAttr specifies a function to generate a list of node data fields.
+//
+// Values are written as strings.  Field names id and label are reserved.
+func NodeAttr(f func(graph.NI) []AttrVal) Option { return export.NodeAttr(f) }
+
+// NodeLabel specifies a function to generate node labels.
+//
+// Labels are written as the data field "label."
+func NodeLabel(f func(graph.NI) string) Option { return export.NodeLabel(f) }
+
+// NodePos specifies node positions, as returned for example by
+// graph.Euclidean or graph.Geometric.
+//
+// The slice must have the length of the graph.  Positions are written as
+// the "position" member of each node.  Positions with a NaN or infinite
+// coordinate are omitted.  Note that Cytoscape's y axis points down.
+func NodePos(pos []struct{ X, Y float64 }) Option { return export.NodePos(pos) }
+
+// String generates a Cytoscape JSON string for a graph.
+//
+// See Write for supported graph types.
+func String(g interface{}, options ...Option) (string, error) {
+	var b bytes.Buffer
+	if err := Write(g, &b, options...); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// Write writes Cytoscape JSON for a graph to an io.Writer.
+//
+// g may be any of:
+//
+//	AdjacencyList
+//	Directed
+//	Undirected
+//	LabeledAdjacencyList
+//	LabeledDirected
+//	LabeledUndirected
+//
+// or a pointer to any of these types.  AdjacencyList and LabeledAdjacencyList
+// are written as directed graphs.  For undirected types, all arcs between
+// distinct nodes must occur in reciprocal pairs and each pair is written as
+// a single edge.
+func Write(g interface{}, w io.Writer, options ...Option) error {
+	gr, err := export.NewGraph("cytoscape", g)
+	if err != nil {
+		return err
+	}
+	cf, err := export.Configure("cytoscape", gr, Defaults, options)
+	if err != nil {
+		return err
+	}
+	b := bufio.NewWriter(w)
+	fmt.Fprintf(b, `{"data":{"directed":%t},"elements":{"nodes":[`,
+		gr.Directed)
+	for n := 0; n < gr.Order; n++ {
+		ni := graph.NI(n)
+		if n > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString("\n" + `{"data":{"id":` + str(strconv.Itoa(n)))
+		if cf.NodeLabel != nil {
+			b.WriteString(`,"label":` + str(cf.NodeLabel(ni)))
+		}
+		if cf.NodeAttr != nil {
+			if err := writeData(b, cf.NodeAttr(ni), "id", "label"); err != nil {
+				return err
+			}
+		}
+		b.WriteByte('}')
+		if cf.NodePos != nil {
+			p := cf.NodePos[n]
+			x, okX := export.FmtFloat(p.X)
+			y, okY := export.FmtFloat(p.Y)
+			if okX && okY {
+				b.WriteString(`,"position":{"x":` + x + `,"y":` + y + "}")
+			}
+		}
+		b.WriteByte('}')
+	}
+	b.WriteString("\n" + `],"edges":[`)
+	for i, e := range gr.Edges {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString("\n" + `{"data":{"id":` + str("e"+strconv.Itoa(i)) +
+			`,"source":` + str(strconv.Itoa(int(e.Fr))) +
+			`,"target":` + str(strconv.Itoa(int(e.To))))
+		if gr.Labeled {
+			if cf.EdgeLabel != nil {
+				b.WriteString(`,"label":` + str(cf.EdgeLabel(e.L)))
+			}
+			if cf.EdgeWeight != nil {
+				if w, ok := export.FmtFloat(cf.EdgeWeight(e.L)); ok {
+					b.WriteString(`,"weight":` + w)
+				}
+			}
+			if cf.EdgeAttr != nil {
+				err := writeData(b, cf.EdgeAttr(e.L),
+					"id", "source", "target", "label", "weight")
+				if err != nil {
+					return err
+				}
+			}
+		}
+		b.WriteString("}}")
+	}
+	if _, err := b.WriteString("\n]}}\n"); err != nil {
+		return err
+	}
+	return b.Flush()
+}
+
+// writeData writes data fields, which must not use reserved names.
+func writeData(b *bufio.Writer, a []AttrVal, reserved ...string) error {
+	for _, av := range a {
+		for _, r := range reserved {
+			if av.Attr == r {
+				return fmt.Errorf("cytoscape: data field %q is reserved", r)
+			}
+		}
+		b.WriteString("," + str(av.Attr) + ":" + str(av.Val))
+	}
+	return nil
+}
+
+// str returns s as a JSON string.
+func str(s string) string {
+	j, _ := json.Marshal(s)
+	return string(j)
+}
@@ -0,0 +1,142 @@
+// Copyright 2026 Sonia Keys
+// License MIT: https://opensource.org/licenses/MIT
+
+package cytoscape_test
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/soniakeys/graph"
+	"github.com/soniakeys/graph/cytoscape"
+)
+
+func ExampleWrite() {
+	// 0---1
+	//  \ /
+	//   2
+	var g graph.Undirected
+	g.AddEdge(0, 1)
+	g.AddEdge(0, 2)
+	g.AddEdge(1, 2)
+	cytoscape.Write(g, os.Stdout)
+	// Output:
+	// {"data":{"directed":false},"elements":{"nodes":[
+	// {"data":{"id":"0"}},
+	// {"data":{"id":"1"}},
+	// {"data":{"id":"2"}}
+	// ],"edges":[
+	// {"data":{"id":"e0","source":"0","target":"1"}},
+	// {"data":{"id":"e1","source":"0","target":"2"}},
+	// {"data":{"id":"e2","source":"1","target":"2"}}
+	// ]}}
+}
+
+func ExampleNodePos() {
+	g := graph.LabeledDirected{graph.LabeledAdjacencyList{
+		0: {{To: 1, Label: 0}},
+		1: nil,
+	}}
+	pos := []struct{ X, Y float64 }{{0, 0}, {1.5, 2}}
+	w := []float64{3.5}
+	cytoscape.Write(g, os.Stdout,
+		cytoscape.NodePos(pos),
+		cytoscape.NodeLabel(func(n graph.NI) string {
+			return "n" + strconv.Itoa(int(n))
+		}),
+		cytoscape.NodeAttr(func(n graph.NI) []cytoscape.AttrVal {
+			return []cytoscape.AttrVal{
+				{Attr: "color", Val: []string{"red", "blue"}[n]}}
+		}),
+		cytoscape.EdgeWeight(func(l graph.LI) float64 { return w[l] }),
+		cytoscape.EdgeAttr(func(l graph.LI) []cytoscape.AttrVal {
+			return []cytoscape.AttrVal{{Attr: "kind", Val: `"road"`}}
+		}))
+	// Output:
+	// {"data":{"directed":true},"elements":{"nodes":[
+	// {"data":{"id":"0","label":"n0","color":"red"},"position":{"x":0,"y":0}},
+	// {"data":{"id":"1","label":"n1","color":"blue"},"position":{"x":1.5,"y":2}}
+	// ],"edges":[
+	// {"data":{"id":"e0","source":"0","target":"1","weight":3.5,"kind":"\"road\""}}
+	// ]}}
+}
+
+func TestValidJSON(t *testing.T) {
+	g, pos, wt := graph.LabeledGeometric(50, .3, nil)
+	s, err := cytoscape.String(g, cytoscape.NodePos(pos),
+		cytoscape.EdgeWeight(func(l graph.LI) float64 { return wt[l] }),
+		cytoscape.EdgeLabel(func(l graph.LI) string { return "<l>" }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		Data     struct{ Directed bool }
+		Elements struct {
+			Nodes []struct {
+				Position struct{ X, Y float64 }
+			}
+			Edges []struct {
+				Data struct{ Weight float64 }
+			}
+		}
+	}
+	if err := json.Unmarshal([]byte(s), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.Data.Directed ||
+		len(doc.Elements.Nodes) != g.Order() ||
+		len(doc.Elements.Edges) != g.Size() {
+		t.Fatal("graph mismatch")
+	}
+	if doc.Elements.Nodes[7].Position.X != pos[7].X {
+		t.Fatal("position mismatch")
+	}
+}
+
+func TestErrors(t *testing.T) {
+	if _, err := cytoscape.String(graph.Undirected{graph.AdjacencyList{{1}, nil}}); err == nil {
+		t.Error("unpaired arc not detected")
+	}
+	if _, err := cytoscape.String(graph.Directed{graph.AdjacencyList{nil}},
+		cytoscape.NodePos(make([]struct{ X, Y float64 }, 2))); err == nil {
+		t.Error("position length not checked")
+	}
+	_, err := cytoscape.String(graph.Directed{graph.AdjacencyList{nil}},
+		cytoscape.NodeAttr(func(graph.NI) []cytoscape.AttrVal {
+			return []cytoscape.AttrVal{{Attr: "id", Val: "x"}}
+		}))
+	if err == nil {
+		t.Error("reserved field not detected")
+	}
+	if _, err := cytoscape.String(3); err == nil {
+		t.Error("unknown type not detected")
+	}
+}
+
+func TestNonFinite(t *testing.T) {
+	g := graph.LabeledDirected{graph.LabeledAdjacencyList{
+		0: {{To: 1, Label: 0}},
+		1: {{To: 0, Label: 1}},
+	}}
+	w := []float64{math.NaN(), math.Inf(1)}
+	pos := []struct{ X, Y float64 }{{math.Inf(-1), 0}, {1, 2}}
+	s, err := cytoscape.String(g, cytoscape.NodePos(pos),
+		cytoscape.EdgeWeight(func(l graph.LI) float64 { return w[l] }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(s, "null") || strings.Contains(s, `"weight"`) {
+		t.Fatal("non-finite value written:\n" + s)
+	}
+	if !strings.Contains(s, `"position":{"x":1,"y":2}`) {
+		t.Fatal("finite position missing:\n" + s)
+	}
+	var doc interface{}
+	if err := json.Unmarshal([]byte(s), &doc); err != nil {
+		t.Fatal(err)
+	}
+}
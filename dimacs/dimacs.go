@@ -0,0 +1,463 @@
+// Copyright 2026 Sonia Keys
+// License MIT: https://opensource.org/licenses/MIT
+
+// Package dimacs reads and writes graphs in DIMACS challenge formats.
+//
+// Supported are the shortest path format (.gr) and coordinate format (.co)
+// of the 9th DIMACS Implementation Challenge, the max-flow format of the
+// 1st challenge, and the edge format used for the clique and coloring
+// problems of the 2nd challenge.
+//
+// Like package dot, dimacs is a separate package from graph.  It imports
+// graph; graph knows nothing of dimacs.
+//
+// DIMACS files number nodes from 1.  Node n of a file becomes graph.NI n-1.
+// Readers accept node counts up to MaxOrder.
+//
+// Arc weights and capacities are returned in a slice indexed by arc label.
+// The label of each arc is simply the zero-based position of the arc line
+// in the file.  A weight function for search methods of package graph
+// is then
+//
+//	func(l graph.LI) float64 { return wt[l] }
+//
+// The edge format has no weights but edges are labeled the same way, by the
+// zero-based position of the edge line in the file.
+//
+// Writers take a graph.WeightFunc so that graphs from any source can be
+// written.  Weights are written in decimal with no exponent, as integers
+// when they are integers.  Note that DIMACS formats specify integer weights.
+package dimacs
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/soniakeys/graph"
+)
+
+// MaxOrder is the maximum node count accepted from a problem line.
+//
+// Isolated nodes take no lines of a DIMACS file, so a short file can declare
+// a graph of any order.  Readers return an error for a node count greater
+// than MaxOrder rather than allocate a graph of that order.  The default
+// accommodates the largest graphs of the 9th DIMACS Implementation
+// Challenge.  Set MaxOrder higher to read larger graphs, or lower when
+// reading untrusted input.
+var MaxOrder = 1 << 25
+
+// allocMax limits preallocation from counts of a problem line.
+//
+// Counts are not trusted until the lines they describe have been read.
+// Slices start no larger than this and grow as lines are read.  Nodes are
+// allocated as arcs reference them, and extended to the node count, at most
+// MaxOrder, only after all lines have been read.
+const allocMax = 1 << 16
+
+// scanner reads the lines of a DIMACS file, skipping comments.
+type scanner struct {
+	s    *bufio.Scanner
+	line int      // line number of current line
+	f    []string // fields of current line
+}
+
+func newScanner(r io.Reader) *scanner {
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, 1<<20)
+	return &scanner{s: s}
+}
+
+// next advances to the next non-blank, non-comment line, returning false
+// at end of input.
+func (s *scanner) next() bool {
+	for s.s.Scan() {
+		s.line++
+		s.f = strings.Fields(s.s.Text())
+		if len(s.f) > 0 && s.f[0] != "c" {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *scanner) errorf(format string, a ...interface{}) error {
+	return fmt.Errorf("dimacs: line %d: %s", s.line, fmt.Sprintf(format, a...))
+}
+
+// err returns the scanner error or an error for a missing line.
+func (s *scanner) err(want string) error {
+	if err := s.s.Err(); err != nil {
+		return err
+	}
+	return fmt.Errorf("dimacs: missing %s", want)
+}
+
+// problem reads the problem line, returning the node and arc counts.
+//
+// Argument types lists acceptable problem types.  Argument aux, if non-empty,
+// lists required auxiliary fields preceding the problem type.
+func (s *scanner) problem(aux string, types ...string) (n, m int, err error) {
+	if !s.next() {
+		return 0, 0, s.err("problem line")
+	}
+	f := s.f
+	if f[0] != "p" {
+		return 0, 0, s.errorf("expected problem line")
+	}
+	f = f[1:]
+	for _, a := range strings.Fields(aux) {
+		if len(f) == 0 || f[0] != a {
+			return 0, 0, s.errorf("expected %q", a)
+		}
+		f = f[1:]
+	}
+	if len(f) == 0 {
+		return 0, 0, s.errorf("missing problem type")
+	}
+	ok := false
+	for _, t := range types {
+		ok = ok || f[0] == t
+	}
+	if !ok {
+		return 0, 0, s.errorf("unsupported problem type %q", f[0])
+	}
+	var c []int
+	for _, x := range f[1:] {
+		// counts must fit graph.NI and graph.LI
+		i, err := strconv.Atoi(x)
+		if err != nil || i < 0 || i > math.MaxInt32 {
+			return 0, 0, s.errorf("invalid count %q", x)
+		}
+		c = append(c, i)
+	}
+	switch {
+	case len(c) == 0:
+		return 0, 0, s.errorf("missing node count")
+	case c[0] > MaxOrder:
+		return 0, 0, s.errorf("node count %d exceeds MaxOrder %d",
+			c[0], MaxOrder)
+	case len(c) == 1 && aux == "":
+		return 0, 0, s.errorf("missing arc count")
+	case len(c) == 1:
+		return c[0], 0, nil
+	}
+	return c[0], c[1], nil
+}
+
+// node parses a 1-based node number, returning the corresponding NI.
+//
+// Order must not exceed math.MaxInt32, as checked by problem, so that valid
+// node numbers fit in graph.NI.
+func (s *scanner) node(x string, order int) (graph.NI, error) {
+	n, err := strconv.ParseInt(x, 10, 32)
+	if err != nil || n < 1 || n > int64(order) {
+		return -1, s.errorf("invalid node %q", x)
+	}
+	return graph.NI(n - 1), nil
+}
+
+// arc parses a line of the form "<d> u v [w]" where <d> is the line
+// descriptor, already checked.
+//
+// If weighted is true, w is required and parsed, otherwise it must be absent.
+func (s *scanner) arc(order int, weighted bool) (u, v graph.NI, w float64, err error) {
+	nf := 3
+	if weighted {
+		nf = 4
+	}
+	if len(s.f) != nf {
+		return -1, -1, 0, s.errorf("expected %d fields", nf)
+	}
+	if u, err = s.node(s.f[1], order); err != nil {
+		return
+	}
+	if v, err = s.node(s.f[2], order); err != nil {
+		return
+	}
+	if weighted {
+		if w, err = strconv.ParseFloat(s.f[3], 64); err != nil {
+			err = s.errorf("invalid weight %q", s.f[3])
+		}
+	}
+	return
+}
+
+// minAlloc returns c bounded by allocMax.
+func minAlloc(c int) int {
+	if c > allocMax {
+		return allocMax
+	}
+	return c
+}
+
+// extend extends s as needed to include index n.
+func extend[S ~[]E, E any](s S, n graph.NI) S {
+	if int(n) >= len(s) {
+		s = append(s, make(S, int(n)+1-len(s))...)
+	}
+	return s
+}
+
+// arcs reads arc lines "a u v w".
+//
+// Lines with descriptor "n" are passed to function nd, if non-nil.
+func (s *scanner) arcs(order, ma int, nd func() error) (graph.LabeledDirected, []float64, error) {
+	var g graph.LabeledAdjacencyList
+	wt := make([]float64, 0, minAlloc(ma))
+	for s.next() {
+		switch s.f[0] {
+		case "a":
+			if len(wt) == ma {
+				return graph.LabeledDirected{}, nil,
+					s.errorf("more than %d arcs", ma)
+			}
+			u, v, w, err := s.arc(order, true)
+			if err != nil {
+				return graph.LabeledDirected{}, nil, err
+			}
+			g = extend(g, u)
+			g[u] = append(g[u], graph.Half{To: v, Label: graph.LI(len(wt))})
+			wt = append(wt, w)
+		case "n":
+			if nd != nil {
+				if err := nd(); err != nil {
+					return graph.LabeledDirected{}, nil, err
+				}
+				continue
+			}
+			fallthrough
+		default:
+			return graph.LabeledDirected{}, nil,
+				s.errorf("unexpected line descriptor %q", s.f[0])
+		}
+	}
+	if err := s.s.Err(); err != nil {
+		return graph.LabeledDirected{}, nil, err
+	}
+	if len(wt) != ma {
+		return graph.LabeledDirected{}, nil,
+			fmt.Errorf("dimacs: found %d arcs, problem line specified %d",
+				len(wt), ma)
+	}
+	return graph.LabeledDirected{extend(g, graph.NI(order-1))}, wt, nil
+}
+
+// ReadSP reads a shortest path problem in the format of the 9th DIMACS
+// Implementation Challenge, commonly with a .gr file extension.
+//
+// Returned is the graph and arc weights indexed by arc label.
+func ReadSP(r io.Reader) (g graph.LabeledDirected, wt []float64, err error) {
+	s := newScanner(r)
+	n, m, err := s.problem("", "sp")
+	if err != nil {
+		return
+	}
+	return s.arcs(n, m, nil)
+}
+
+// ReadCo reads node coordinates in the format of the 9th DIMACS
+// Implementation Challenge, commonly with a .co file extension.
+//
+// The coordinates are returned indexed by node in the same form returned by
+// graph.Euclidean and graph.Geometric.  Nodes not listed in the file have
+// coordinates 0, 0.
+func ReadCo(r io.Reader) (pos []struct{ X, Y float64 }, err error) {
+	s := newScanner(r)
+	n, _, err := s.problem("aux sp", "co")
+	if err != nil {
+		return
+	}
+	for s.next() {
+		if s.f[0] != "v" || len(s.f) != 4 {
+			return nil, s.errorf("expected coordinate line")
+		}
+		v, err := s.node(s.f[1], n)
+		if err != nil {
+			return nil, err
+		}
+		pos = extend(pos, v)
+		p := &pos[v]
+		if p.X, err = strconv.ParseFloat(s.f[2], 64); err != nil {
+			return nil, s.errorf("invalid coordinate %q", s.f[2])
+		}
+		if p.Y, err = strconv.ParseFloat(s.f[3], 64); err != nil {
+			return nil, s.errorf("invalid coordinate %q", s.f[3])
+		}
+	}
+	if err := s.s.Err(); err != nil {
+		return nil, err
+	}
+	return extend(pos, graph.NI(n-1)), nil
+}
+
+// ReadMaxFlow reads a max-flow problem in the format of the 1st DIMACS
+// Implementation Challenge.
+//
+// Returned is the graph, arc capacities indexed by arc label, and the
+// source and sink nodes.
+func ReadMaxFlow(r io.Reader) (g graph.LabeledDirected, capacity []float64, source, sink graph.NI, err error) {
+	s := newScanner(r)
+	n, m, err := s.problem("", "max")
+	if err != nil {
+		return
+	}
+	source, sink = -1, -1
+	nd := func() error {
+		if len(s.f) != 3 {
+			return s.errorf("expected 3 fields")
+		}
+		v, err := s.node(s.f[1], n)
+		if err != nil {
+			return err
+		}
+		switch s.f[2] {
+		case "s":
+			source = v
+		case "t":
+			sink = v
+		default:
+			return s.errorf("invalid node designator %q", s.f[2])
+		}
+		return nil
+	}
+	if g, capacity, err = s.arcs(n, m, nd); err != nil {
+		return
+	}
+	if source < 0 || sink < 0 {
+		err = fmt.Errorf("dimacs: missing source or sink")
+	}
+	return
+}
+
+// ReadEdge reads an undirected graph in the DIMACS edge format as used
+// for the clique and coloring problems of the 2nd DIMACS Implementation
+// Challenge.
+//
+// Problem types "edge" and "col" are accepted.  Node weight lines, if
+// present, are not supported.  Reciprocal arcs of each edge share a label,
+// the zero-based position of the edge line in the file.
+func ReadEdge(r io.Reader) (g graph.LabeledUndirected, err error) {
+	s := newScanner(r)
+	n, m, err := s.problem("", "edge", "col")
+	if err != nil {
+		return
+	}
+	ne := 0
+	for s.next() {
+		if s.f[0] != "e" {
+			return graph.LabeledUndirected{},
+				s.errorf("unexpected line descriptor %q", s.f[0])
+		}
+		u, v, _, err := s.arc(n, false)
+		if err != nil {
+			return graph.LabeledUndirected{}, err
+		}
+		g.LabeledAdjacencyList = extend(extend(g.LabeledAdjacencyList, u), v)
+		g.AddEdge(graph.Edge{u, v}, graph.LI(ne))
+		ne++
+	}
+	if err = s.s.Err(); err != nil {
+		return graph.LabeledUndirected{}, err
+	}
+	if ne != m {
+		return graph.LabeledUndirected{},
+			fmt.Errorf("dimacs: found %d edges, problem line specified %d",
+				ne, m)
+	}
+	g.LabeledAdjacencyList = extend(g.LabeledAdjacencyList, graph.NI(n-1))
+	return
+}
+
+// fmtWeight formats a weight without an exponent.
+func fmtWeight(w float64) string {
+	return strconv.FormatFloat(w, 'f', -1, 64)
+}
+
+// writeArcs writes arc lines for g, for the problem line already written.
+func writeArcs(b *bufio.Writer, g graph.LabeledAdjacencyList, w graph.WeightFunc) error {
+	for fr, to := range g {
+		for _, to := range to {
+			_, err := fmt.Fprintf(b, "a %d %d %s\n",
+				fr+1, to.To+1, fmtWeight(w(to.Label)))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return b.Flush()
+}
+
+// WriteSP writes a shortest path problem in the format of the 9th DIMACS
+// Implementation Challenge.
+//
+// WeightFunc w gives arc weights.
+func WriteSP(wr io.Writer, g graph.LabeledDirected, w graph.WeightFunc) error {
+	b := bufio.NewWriter(wr)
+	_, err := fmt.Fprintf(b, "p sp %d %d\n", g.Order(), g.ArcSize())
+	if err != nil {
+		return err
+	}
+	return writeArcs(b, g.LabeledAdjacencyList, w)
+}
+
+// WriteCo writes node coordinates in the format of the 9th DIMACS
+// Implementation Challenge.
+func WriteCo(wr io.Writer, pos []struct{ X, Y float64 }) error {
+	b := bufio.NewWriter(wr)
+	if _, err := fmt.Fprintf(b, "p aux sp co %d\n", len(pos)); err != nil {
+		return err
+	}
+	for n, p := range pos {
+		_, err := fmt.Fprintf(b, "v %d %s %s\n",
+			n+1, fmtWeight(p.X), fmtWeight(p.Y))
+		if err != nil {
+			return err
+		}
+	}
+	return b.Flush()
+}
+
+// WriteMaxFlow writes a max-flow problem in the format of the 1st DIMACS
+// Implementation Challenge.
+//
+// WeightFunc capacity gives arc capacities.
+func WriteMaxFlow(wr io.Writer, g graph.LabeledDirected, capacity graph.WeightFunc, source, sink graph.NI) error {
+	b := bufio.NewWriter(wr)
+	_, err := fmt.Fprintf(b, "p max %d %d\nn %d s\nn %d t\n",
+		g.Order(), g.ArcSize(), source+1, sink+1)
+	if err != nil {
+		return err
+	}
+	return writeArcs(b, g.LabeledAdjacencyList, capacity)
+}
+
+// WriteEdge writes an undirected graph in the DIMACS edge format as used
+// for the clique and coloring problems of the 2nd DIMACS Implementation
+// Challenge.
+//
+// Each reciprocal arc pair of g is written as a single edge, with the lesser
+// node number first.  Loops are written as single edges as well.
+func WriteEdge(wr io.Writer, g graph.Undirected) error {
+	var el []graph.Edge
+	g.Edges(func(e graph.Edge) {
+		if e.N1 > e.N2 {
+			e.N1, e.N2 = e.N2, e.N1
+		}
+		el = append(el, e)
+	})
+	b := bufio.NewWriter(wr)
+	_, err := fmt.Fprintf(b, "p edge %d %d\n", g.Order(), len(el))
+	if err != nil {
+		return err
+	}
+	for _, e := range el {
+		if _, err = fmt.Fprintf(b, "e %d %d\n", e.N1+1, e.N2+1); err != nil {
+			return err
+		}
+	}
+	return b.Flush()
+}
@@ -0,0 +1,272 @@
+// Copyright 2026 Sonia Keys
+// License MIT: https://opensource.org/licenses/MIT
+
+package dimacs_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/soniakeys/graph"
+	"github.com/soniakeys/graph/dimacs"
+)
+
+func ExampleReadSP() {
+	f := `c a small example
+p sp 4 5
+a 1 2 10
+a 1 3 30
+a 2 3 10
+a 3 4 5
+a 4 1 7
+`
+	g, wt, err := dimacs.ReadSP(strings.NewReader(f))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for fr, to := range g.LabeledAdjacencyList {
+		fmt.Println(fr, to)
+	}
+	fmt.Println(wt)
+	path, dist := g.DijkstraPath(0, 3, func(l graph.LI) float64 {
+		return wt[l]
+	})
+	fmt.Println(path, dist)
+	// Output:
+	// 0 [{1 0} {2 1}]
+	// 1 [{2 2}]
+	// 2 [{3 3}]
+	// 3 [{0 4}]
+	// [10 30 10 5 7]
+	// [0 1 2 3] 25
+}
+
+func ExampleReadCo() {
+	f := `p aux sp co 3
+v 1 -73530767 41085396
+v 2 -73530538 41086098
+v 3 -73519366 41048796
+`
+	pos, err := dimacs.ReadCo(strings.NewReader(f))
+	fmt.Println(err)
+	for n, p := range pos {
+		fmt.Printf("%d %.0f %.0f\n", n, p.X, p.Y)
+	}
+	// Output:
+	// <nil>
+	// 0 -73530767 41085396
+	// 1 -73530538 41086098
+	// 2 -73519366 41048796
+}
+
+func ExampleReadMaxFlow() {
+	f := `p max 4 5
+n 1 s
+n 4 t
+a 1 2 4
+a 1 3 2
+a 2 3 1
+a 2 4 2
+a 3 4 3
+`
+	g, c, s, t, err := dimacs.ReadMaxFlow(strings.NewReader(f))
+	fmt.Println(err)
+	fmt.Println("source", s, "sink", t)
+	for fr, to := range g.LabeledAdjacencyList {
+		fmt.Println(fr, to)
+	}
+	fmt.Println(c)
+	// Output:
+	// <nil>
+	// source 0 sink 3
+	// 0 [{1 0} {2 1}]
+	// 1 [{2 2} {3 3}]
+	// 2 [{3 4}]
+	// 3 []
+	// [4 2 1 2 3]
+}
+
+func ExampleReadEdge() {
+	f := `c a triangle plus a pendant node
+p edge 4 4
+e 1 2
+e 1 3
+e 2 3
+e 3 4
+`
+	g, err := dimacs.ReadEdge(strings.NewReader(f))
+	fmt.Println(err)
+	for fr, to := range g.LabeledAdjacencyList {
+		fmt.Println(fr, to)
+	}
+	// Output:
+	// <nil>
+	// 0 [{1 0} {2 1}]
+	// 1 [{0 0} {2 2}]
+	// 2 [{0 1} {1 2} {3 3}]
+	// 3 [{2 3}]
+}
+
+func ExampleWriteSP() {
+	//        0
+	// (10) /   \ (20)
+	//     1---->2
+	//      (30)
+	g := graph.LabeledDirected{graph.LabeledAdjacencyList{
+		0: {{To: 1, Label: 10}, {To: 2, Label: 20}},
+		1: {{To: 2, Label: 30}},
+		2: nil,
+	}}
+	dimacs.WriteSP(os.Stdout, g, func(l graph.LI) float64 {
+		return float64(l)
+	})
+	// Output:
+	// p sp 3 3
+	// a 1 2 10
+	// a 1 3 20
+	// a 2 3 30
+}
+
+func ExampleWriteCo() {
+	pos := []struct{ X, Y float64 }{{1, 2}, {1.5, 1e6}}
+	dimacs.WriteCo(os.Stdout, pos)
+	// Output:
+	// p aux sp co 2
+	// v 1 1 2
+	// v 2 1.5 1000000
+}
+
+func ExampleWriteMaxFlow() {
+	g := graph.LabeledDirected{graph.LabeledAdjacencyList{
+		0: {{To: 1, Label: 0}, {To: 2, Label: 1}},
+		1: {{To: 2, Label: 2}},
+		2: nil,
+	}}
+	c := []float64{5, 3, 2}
+	dimacs.WriteMaxFlow(os.Stdout, g, func(l graph.LI) float64 {
+		return c[l]
+	}, 0, 2)
+	// Output:
+	// p max 3 3
+	// n 1 s
+	// n 3 t
+	// a 1 2 5
+	// a 1 3 3
+	// a 2 3 2
+}
+
+func ExampleWriteEdge() {
+	// 0---1
+	//  \ /
+	//   2---3
+	var g graph.Undirected
+	g.AddEdge(0, 1)
+	g.AddEdge(0, 2)
+	g.AddEdge(1, 2)
+	g.AddEdge(2, 3)
+	dimacs.WriteEdge(os.Stdout, g)
+	// Output:
+	// p edge 4 4
+	// e 1 2
+	// e 1 3
+	// e 2 3
+	// e 3 4
+}
+
+func TestRoundTrip(t *testing.T) {
+	g, _, wt, err := graph.LabeledEuclidean(100, 400, 1, 10, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b bytes.Buffer
+	w := func(l graph.LI) float64 { return wt[l] }
+	if err := dimacs.WriteSP(&b, g, w); err != nil {
+		t.Fatal(err)
+	}
+	g2, wt2, err := dimacs.ReadSP(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for fr, to := range g.LabeledAdjacencyList {
+		to2 := g2.LabeledAdjacencyList[fr]
+		if len(to2) != len(to) {
+			t.Fatal("arc count mismatch at node", fr)
+		}
+		for x, h := range to {
+			h2 := to2[x]
+			if h2.To != h.To || wt2[h2.Label] != wt[h.Label] {
+				t.Fatal("arc mismatch at node", fr)
+			}
+		}
+	}
+}
+
+func TestErrors(t *testing.T) {
+	for _, f := range []string{
+		"",
+		"a 1 2 3\n",
+		"p max 2 1\na 1 2 3\n",
+		"p sp 2 1\na 1 3 3\n",
+		"p sp 2 1\na 1 2\n",
+		"p sp 2 1\na 1 2 x\n",
+		"p sp 2 2\na 1 2 3\n",
+		"p sp 2 1\na 1 2 3\na 2 1 3\n",
+		"p sp 4294967297 1\na 1 2 3\n",          // order out of range
+		"p sp 2 1\na 4294967297 2 3\n",          // node out of range
+		"p sp 2147483647 2147483647\n",          // hostile counts
+		"p sp 2147483647 1\na 1 2147483648 3\n", // node out of range
+	} {
+		if _, _, err := dimacs.ReadSP(strings.NewReader(f)); err == nil {
+			t.Errorf("no error for %q", f)
+		}
+	}
+	_, _, _, _, err := dimacs.ReadMaxFlow(strings.NewReader(
+		"p max 2 1\nn 1 s\na 1 2 3\n"))
+	if err == nil {
+		t.Error("missing sink not detected")
+	}
+	if _, err := dimacs.ReadEdge(strings.NewReader(
+		"p edge 2147483647 2147483647\ne 1 2\n")); err == nil {
+		t.Error("hostile edge count not detected")
+	}
+	// isolated nodes are still allocated to the order of the problem line
+	g, _, err := dimacs.ReadSP(strings.NewReader("p sp 5 1\na 1 2 3\n"))
+	if err != nil || g.Order() != 5 {
+		t.Error("order", g.Order(), err)
+	}
+	u, err := dimacs.ReadEdge(strings.NewReader("p edge 5 1\ne 3 2\n"))
+	if err != nil || u.Order() != 5 {
+		t.Error("edge order", u.Order(), err)
+	}
+	pos, err := dimacs.ReadCo(strings.NewReader("p aux sp co 5\nv 2 1 1\n"))
+	if err != nil || len(pos) != 5 || pos[1].X != 1 {
+		t.Error("coordinates", pos, err)
+	}
+	// hostile node counts, valid otherwise, must fail without attempting
+	// large allocations.
+	if _, _, err := dimacs.ReadSP(strings.NewReader("p sp 2000000000 0\n")); err == nil {
+		t.Error("hostile sp node count not detected")
+	}
+	if _, err := dimacs.ReadEdge(strings.NewReader("p edge 2000000000 0\n")); err == nil {
+		t.Error("hostile edge node count not detected")
+	}
+	if _, err := dimacs.ReadCo(strings.NewReader("p aux sp co 2000000000\n")); err == nil {
+		t.Error("hostile co node count not detected")
+	}
+	if _, _, _, _, err := dimacs.ReadMaxFlow(strings.NewReader(
+		"p max 2000000000 0\n")); err == nil {
+		t.Error("hostile max node count not detected")
+	}
+	defer func(m int) { dimacs.MaxOrder = m }(dimacs.MaxOrder)
+	dimacs.MaxOrder = 10
+	if g, _, err := dimacs.ReadSP(strings.NewReader("p sp 10 0\n")); err != nil || g.Order() != 10 {
+		t.Error("MaxOrder", g.Order(), err)
+	}
+	if _, _, err := dimacs.ReadSP(strings.NewReader("p sp 11 0\n")); err == nil {
+		t.Error("MaxOrder exceeded not detected")
+	}
+}
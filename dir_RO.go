@@ -5,6 +5,7 @@ package graph
 
 import (
 	"errors"
+	"iter"
 
 	"github.com/soniakeys/bits"
 )
@@ -533,6 +534,19 @@ func (g Directed) MaximalNonBranchingPaths(emit func([]NI) bool) {
 	}
 }
 
+// MaximalNonBranchingPathsSeq returns an iterator over maximal
+// non-branching paths of g.
+//
+// The iterator yields the paths that MaximalNonBranchingPaths would pass to
+// its emit function.
+//
+// There are equivalent labeled and unlabeled versions of this method.
+func (g Directed) MaximalNonBranchingPathsSeq() iter.Seq[[]NI] {
+	return func(yield func([]NI) bool) {
+		g.MaximalNonBranchingPaths(yield)
+	}
+}
+
 // FromList transposes a labeled graph into a FromList.
 //
 // Receiver g should be connected as a tree or forest.  Specifically no node
@@ -686,6 +700,19 @@ func (g Directed) StronglyConnectedComponents(emit func([]NI) bool) {
 	}
 }
 
+// StronglyConnectedComponentsSeq returns an iterator over strongly
+// connected components of g.
+//
+// The iterator yields the node lists that StronglyConnectedComponents
+// would pass to its emit function.
+//
+// There are equivalent labeled and unlabeled versions of this method.
+func (g Directed) StronglyConnectedComponentsSeq() iter.Seq[[]NI] {
+	return func(yield func([]NI) bool) {
+		g.StronglyConnectedComponents(yield)
+	}
+}
+
 // Condensation returns strongly connected components and their
 // condensation graph.
 //
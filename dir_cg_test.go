@@ -437,6 +437,34 @@ func ExampleLabeledDirected_MaximalNonBranchingPaths() {
 	// 5 --e-- 6 --f-- 5
 }
 
+func ExampleLabeledDirected_MaximalNonBranchingPathsSeq() {
+	//   a    b     c
+	// 0--->1---->2---->3
+	//             \ d
+	//    -->6      ---->4
+	// e /  / f
+	//  5<--
+	g := graph.LabeledDirected{graph.LabeledAdjacencyList{
+		0: {{1, 'a'}},
+		1: {{2, 'b'}},
+		2: {{3, 'c'}, {4, 'd'}},
+		5: {{6, 'e'}},
+		6: {{5, 'f'}},
+	}}
+	for p := range g.MaximalNonBranchingPathsSeq() {
+		fmt.Print(p[0].To)
+		for _, to := range p[1:] {
+			fmt.Printf(" --%c-- %d", to.Label, to.To)
+		}
+		fmt.Println()
+	}
+	// Output:
+	// 0 --a-- 1 --b-- 2
+	// 2 --c-- 3
+	// 2 --d-- 4
+	// 5 --e-- 6 --f-- 5
+}
+
 func ExampleLabeledDirected_PostDominators() {
 	// Example graph here is transpose of that in the Dominators example
 	// to show result is the same.
@@ -530,6 +530,29 @@ func ExampleDirected_StronglyConnectedComponents() {
 	// [0]
 }
 
+func ExampleDirected_StronglyConnectedComponentsSeq() {
+	// (same graph as StronglyConnectedComponents example)
+	g := graph.Directed{graph.AdjacencyList{
+		0: {0, 5, 7},
+		5: {4, 6},
+		4: {5, 2, 3},
+		7: {6},
+		6: {7, 3},
+		3: {1},
+		1: {2},
+		2: {3},
+	}}
+	for c := range g.StronglyConnectedComponentsSeq() {
+		fmt.Println(c)
+		if len(c) == 2 {
+			break
+		}
+	}
+	// Output:
+	// [3 1 2]
+	// [7 6]
+}
+
 func ExampleDirected_Condensation() {
 	// input:          condensation:
 	// /---0---\      <->  /---0
@@ -0,0 +1,286 @@
+// Copyright 2026 Sonia Keys
+// License MIT: https://opensource.org/licenses/MIT
+
+package graph
+
+import (
+	"container/heap"
+	"sort"
+)
+
+// DynamicSSSP maintains single source shortest paths in a weighted directed
+// graph as arcs are added, removed, or change weight.
+//
+// Repairs follow the approach of Ramalingam and Reps:  Only nodes whose
+// shortest paths may be affected by a change are reconsidered, and
+// distances are repaired with a Dijkstra-like search limited to those nodes
+// and nodes whose distances improve as a result.  The cost of a repair is
+// thus proportional to the size of the change in the shortest path tree
+// rather than the size of the graph.
+//
+// Fields From, Labels, and Dist hold the current shortest path tree and
+// distances.  They are updated by methods of DynamicSSSP and should not be
+// modified otherwise.  As with the Dijkstra method, From.Paths[n].Len is
+// zero for nodes not reached from the start node.  Labels[n] is the label
+// of the arc from From.Paths[n].From to n.  Dist[n] is the shortest path
+// distance to n, or zero for unreached nodes.  Fields From.Leaves and
+// From.MaxLen are not maintained.
+//
+// Construct with NewDynamicSSSP.
+type DynamicSSSP struct {
+	From   FromList
+	Labels []LI
+	Dist   []float64
+
+	g LabeledAdjacencyList
+	t LabeledAdjacencyList // transpose of g, arcs to each node
+	w WeightFunc
+}
+
+// NewDynamicSSSP creates a DynamicSSSP from the result of Dijkstra.
+//
+// Arguments f and dist must be the result of g.Dijkstra(start, -1, w)
+// for some start node, that is, for all paths from the start node.
+// Arc weights must be non-negative.
+//
+// The DynamicSSSP takes ownership of g, f, and dist.  Arguments g, f, and
+// dist should not be used or modified after calling NewDynamicSSSP.
+func NewDynamicSSSP(g LabeledDirected, w WeightFunc, f FromList, dist []float64) *DynamicSSSP {
+	t, _ := g.Transpose()
+	d := &DynamicSSSP{
+		From:   f,
+		Labels: make([]LI, len(f.Paths)),
+		Dist:   dist,
+		g:      g.LabeledAdjacencyList,
+		t:      t.LabeledAdjacencyList,
+		w:      w,
+	}
+	// recover labels of tree arcs
+	for n, pe := range f.Paths {
+		d.Labels[n] = -1
+		if pe.Len <= 1 {
+			continue // start node or unreached
+		}
+		min := -1.
+		for _, to := range d.g[pe.From] {
+			if to.To != NI(n) {
+				continue
+			}
+			dt := dist[pe.From] + w(to.Label)
+			if dt == dist[n] {
+				d.Labels[n] = to.Label
+				break
+			}
+			if min < 0 || dt < min {
+				min = dt
+				d.Labels[n] = to.Label
+			}
+		}
+	}
+	return d
+}
+
+// Graph returns the current graph.
+//
+// The returned graph shares memory with the DynamicSSSP and should not be
+// modified.
+func (d *DynamicSSSP) Graph() LabeledDirected {
+	return LabeledDirected{d.g}
+}
+
+// AddArc adds an arc from node fr to node to.To with label to.Label and
+// repairs shortest paths.
+//
+// Nodes fr and to.To must already exist.  Returned is a list of nodes whose
+// distance changed, including nodes that became reachable.
+func (d *DynamicSSSP) AddArc(fr NI, to Half) (changed []NI) {
+	d.g[fr] = append(d.g[fr], to)
+	d.t[to.To] = append(d.t[to.To], Half{fr, to.Label})
+	return d.repair(nil, fr, to)
+}
+
+// RemoveArc removes an arc from node fr to node to.To with label to.Label
+// and repairs shortest paths.
+//
+// If there are parallel arcs with the same label, only one is removed.
+// Returned is ok = true if an arc was removed, along with a list of nodes
+// whose distance changed, including nodes that became unreachable.
+// If there is no such arc, RemoveArc returns ok = false.
+func (d *DynamicSSSP) RemoveArc(fr NI, to Half) (ok bool, changed []NI) {
+	if !removeHalf(d.g, fr, to) {
+		return false, nil
+	}
+	removeHalf(d.t, to.To, Half{fr, to.Label})
+	if !d.isTreeArc(fr, to) {
+		// shortest paths unaffected
+		return true, nil
+	}
+	return true, d.repair(d.subtree(to.To), -1, Half{})
+}
+
+// WeightChanged repairs shortest paths after a change in the weight of the
+// arc from node fr to node to.To with label to.Label.
+//
+// Call WeightChanged after changing the weight so that the WeightFunc of
+// the DynamicSSSP returns the new weight.  If the label is shared by
+// multiple arcs, call WeightChanged for each.  Weights may increase or
+// decrease but must remain non-negative.
+//
+// Returned is a list of nodes whose distance changed.
+func (d *DynamicSSSP) WeightChanged(fr NI, to Half) (changed []NI) {
+	if d.isTreeArc(fr, to) {
+		return d.repair(d.subtree(to.To), fr, to)
+	}
+	// not a tree arc.  only a decrease can matter.
+	return d.repair(nil, fr, to)
+}
+
+// removeHalf removes a single arc matching h from node fr of g, preserving
+// the order of remaining arcs.
+func removeHalf(g LabeledAdjacencyList, fr NI, h Half) bool {
+	a := g[fr]
+	for x, to := range a {
+		if to == h {
+			g[fr] = append(a[:x], a[x+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (d *DynamicSSSP) isTreeArc(fr NI, to Half) bool {
+	p := d.From.Paths[to.To]
+	return p.Len > 0 && p.From == fr && d.Labels[to.To] == to.Label
+}
+
+// subtree returns the nodes of the shortest path tree rooted at n.
+//
+// Parallel arcs with the same label all satisfy isTreeArc, so nodes are
+// marked as visited to list each only once.
+func (d *DynamicSSSP) subtree(n NI) []NI {
+	s := []NI{n}
+	visited := map[NI]bool{n: true}
+	for i := 0; i < len(s); i++ {
+		fr := s[i]
+		for _, to := range d.g[fr] {
+			if !visited[to.To] && d.isTreeArc(fr, to) {
+				visited[to.To] = true
+				s = append(s, to.To)
+			}
+		}
+	}
+	return s
+}
+
+// repair recomputes shortest paths.
+//
+// Nodes of affected are first reset to unreached, then given tentative
+// distances from arcs from unaffected nodes.  If fr >= 0, the arc from fr
+// to to is additionally relaxed.  A Dijkstra search then proceeds from the
+// tentative nodes, extending to any node whose path improves.
+func (d *DynamicSSSP) repair(affected []NI, fr NI, to Half) (changed []NI) {
+	p := d.From.Paths
+	// record original distances for nodes that may change
+	type old struct {
+		dist    float64
+		reached bool
+	}
+	orig := map[NI]old{}
+	var noted []NI
+	note := func(n NI) {
+		if _, ok := orig[n]; !ok {
+			orig[n] = old{d.Dist[n], p[n].Len > 0}
+			noted = append(noted, n)
+		}
+	}
+	h := &dynHeap{d: d.Dist, x: map[NI]int{}}
+	// relax attempts to improve the path to n through arc l from fr.
+	relax := func(fr NI, n NI, l LI) {
+		if p[fr].Len == 0 {
+			return
+		}
+		dist := d.Dist[fr] + d.w(l)
+		nextLen := p[fr].Len + 1
+		if vl := p[n].Len; vl > 0 {
+			if dist > d.Dist[n] || dist == d.Dist[n] && nextLen >= vl {
+				return
+			}
+		}
+		note(n)
+		d.Dist[n] = dist
+		p[n] = PathEnd{From: fr, Len: nextLen}
+		d.Labels[n] = l
+		h.update(n)
+	}
+	for _, n := range affected {
+		note(n)
+		p[n] = PathEnd{From: -1}
+		d.Labels[n] = -1
+		d.Dist[n] = 0
+	}
+	// at this point orig holds exactly the affected nodes.
+	for _, n := range affected {
+		for _, in := range d.t[n] {
+			if _, aff := orig[in.To]; !aff {
+				relax(in.To, n, in.Label)
+			}
+		}
+	}
+	if fr >= 0 {
+		relax(fr, to.To, to.Label)
+	}
+	for h.Len() > 0 {
+		n := heap.Pop(h).(NI)
+		for _, to := range d.g[n] {
+			relax(n, to.To, to.Label)
+		}
+	}
+	for _, n := range affected {
+		if p[n].Len == 0 {
+			d.Dist[n] = 0
+		}
+	}
+	// report changes in order of node number.
+	sort.Slice(noted, func(i, j int) bool { return noted[i] < noted[j] })
+	for _, n := range noted {
+		if o := orig[n]; o.reached != (p[n].Len > 0) || o.dist != d.Dist[n] {
+			changed = append(changed, n)
+		}
+	}
+	return
+}
+
+// dynHeap implements container/heap for DynamicSSSP.repair.
+type dynHeap struct {
+	n []NI
+	d []float64
+	x map[NI]int // heap index of nodes in heap
+}
+
+func (h *dynHeap) update(n NI) {
+	if x, ok := h.x[n]; ok {
+		heap.Fix(h, x)
+	} else {
+		heap.Push(h, n)
+	}
+}
+
+func (h dynHeap) Len() int           { return len(h.n) }
+func (h dynHeap) Less(i, j int) bool { return h.d[h.n[i]] < h.d[h.n[j]] }
+func (h dynHeap) Swap(i, j int) {
+	h.n[i], h.n[j] = h.n[j], h.n[i]
+	h.x[h.n[i]] = i
+	h.x[h.n[j]] = j
+}
+func (h *dynHeap) Push(x interface{}) {
+	n := x.(NI)
+	h.x[n] = len(h.n)
+	h.n = append(h.n, n)
+}
+func (h *dynHeap) Pop() interface{} {
+	last := len(h.n) - 1
+	n := h.n[last]
+	h.n = h.n[:last]
+	delete(h.x, n)
+	return n
+}
@@ -0,0 +1,132 @@
+// Copyright 2026 Sonia Keys
+// License MIT: https://opensource.org/licenses/MIT
+
+package graph_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/soniakeys/graph"
+)
+
+func ExampleDynamicSSSP() {
+	//      0
+	//   2 / \ 5
+	//    v   v
+	//    1-1->2-1->3
+	g := graph.LabeledDirected{graph.LabeledAdjacencyList{
+		0: {{To: 1, Label: 0}, {To: 2, Label: 1}},
+		1: {{To: 2, Label: 2}},
+		2: {{To: 3, Label: 3}},
+		3: {},
+	}}
+	w := []float64{2, 5, 1, 1}
+	wf := func(l graph.LI) float64 { return w[l] }
+	f, dist, _ := g.Dijkstra(0, -1, wf)
+	d := graph.NewDynamicSSSP(g, wf, f, dist)
+	fmt.Println(d.Dist)
+
+	// increase weight of tree arc 1->2
+	w[2] = 4
+	fmt.Println(d.WeightChanged(1, graph.Half{To: 2, Label: 2}), d.Dist)
+
+	// add a shortcut 0->3
+	w = append(w, 2)
+	fmt.Println(d.AddArc(0, graph.Half{To: 3, Label: 4}), d.Dist)
+
+	// remove arc 0->1
+	_, changed := d.RemoveArc(0, graph.Half{To: 1, Label: 0})
+	fmt.Println(changed, d.Dist, d.From.Paths[1].Len)
+	// Output:
+	// [0 2 3 4]
+	// [2 3] [0 2 5 6]
+	// [3] [0 2 5 2]
+	// [1] [0 0 5 2] 0
+}
+
+func TestDynamicSSSP(t *testing.T) {
+	r := rand.New(rand.NewSource(37))
+	const n = 60
+	g := graph.LabeledDirected{make(graph.LabeledAdjacencyList, n)}
+	var w []float64
+	for i := 0; i < 150; i++ {
+		fr := r.Intn(n)
+		g.LabeledAdjacencyList[fr] = append(g.LabeledAdjacencyList[fr],
+			graph.Half{To: graph.NI(r.Intn(n)), Label: graph.LI(len(w))})
+		w = append(w, float64(r.Intn(10)))
+	}
+	wf := func(l graph.LI) float64 { return w[l] }
+	f, dist, _ := g.Dijkstra(0, -1, wf)
+	c, _ := g.Copy()
+	d := graph.NewDynamicSSSP(c, wf, f, dist)
+	check := func(op string) {
+		wantF, want, _ := d.Graph().Dijkstra(0, -1, wf)
+		for i, p := range wantF.Paths {
+			if (p.Len > 0) != (d.From.Paths[i].Len > 0) || want[i] != d.Dist[i] {
+				t.Fatal(op, "node", i, "dist", d.Dist[i], "want", want[i])
+			}
+			// path must be consistent with tree arc, although with ties
+			// it may differ from the one found by Dijkstra.
+			dp := d.From.Paths[i]
+			if dp.Len <= 1 {
+				continue
+			}
+			if dp.Len != d.From.Paths[dp.From].Len+1 ||
+				d.Dist[i] != d.Dist[dp.From]+w[d.Labels[i]] {
+				t.Fatal(op, "node", i, "inconsistent path")
+			}
+			if ok, _ := d.Graph().HasArcLabel(dp.From, graph.NI(i), d.Labels[i]); !ok {
+				t.Fatal(op, "node", i, "missing tree arc")
+			}
+		}
+	}
+	for i := 0; i < 300; i++ {
+		switch r.Intn(3) {
+		case 0:
+			l := graph.LI(len(w))
+			w = append(w, float64(r.Intn(10)))
+			d.AddArc(graph.NI(r.Intn(n)),
+				graph.Half{To: graph.NI(r.Intn(n)), Label: l})
+			check("add")
+		case 1:
+			fr := graph.NI(r.Intn(n))
+			if to := d.Graph().LabeledAdjacencyList[fr]; len(to) > 0 {
+				if ok, _ := d.RemoveArc(fr, to[r.Intn(len(to))]); !ok {
+					t.Fatal("remove failed")
+				}
+				check("remove")
+			}
+		case 2:
+			fr := graph.NI(r.Intn(n))
+			if to := d.Graph().LabeledAdjacencyList[fr]; len(to) > 0 {
+				h := to[r.Intn(len(to))]
+				w[h.Label] = float64(r.Intn(10))
+				d.WeightChanged(fr, h)
+				check("weight")
+			}
+		}
+	}
+	// parallel arcs with the same label.  a chain of k nodes, each joined to
+	// the next by two such arcs, must not list subtree nodes 2^k times.
+	const k = 22
+	pg := graph.LabeledDirected{make(graph.LabeledAdjacencyList, k+1)}
+	for i := 0; i < k; i++ {
+		h := graph.Half{To: graph.NI(i + 1), Label: 0}
+		pg.LabeledAdjacencyList[i] = []graph.Half{h, h}
+	}
+	one := func(graph.LI) float64 { return 1 }
+	pf, pdist, _ := pg.Dijkstra(0, -1, one)
+	pd := graph.NewDynamicSSSP(pg, one, pf, pdist)
+	arc := graph.Half{To: 1, Label: 0}
+	if changed := pd.WeightChanged(0, arc); len(changed) != 0 {
+		t.Fatal("parallel weight", changed)
+	}
+	if ok, changed := pd.RemoveArc(0, arc); !ok || len(changed) != 0 {
+		t.Fatal("parallel remove", ok, changed)
+	}
+	if ok, changed := pd.RemoveArc(0, arc); !ok || len(changed) != k {
+		t.Fatal("last parallel remove", ok, len(changed))
+	}
+}
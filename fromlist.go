@@ -84,6 +84,8 @@ func (f FromList) BoundsOk() (ok bool, n NI) {
 //
 // The method relies on populated PathEnd.Len members.  Use RecalcLen if
 // the Len members are not known to be present and correct.
+//
+// For repeated queries see LCAIndex.
 func (f FromList) CommonStart(a, b NI) NI {
 	p := f.Paths
 	if p[a].Len < p[b].Len {
@@ -212,6 +214,8 @@ func PathTo(paths []PathEnd, end NI, p []NI) []NI {
 // if v returns false.  Preorder returns true if it completes without v
 // returning false.  Preorder returns false if traversal is terminated by v
 // returning false.
+//
+// See also Postorder.
 func (f FromList) Preorder(v func(NI) bool) bool {
 	p := f.Paths
 	done := bits.New(len(p))
@@ -0,0 +1,252 @@
+// Copyright 2026 Sonia Keys
+// License MIT: https://opensource.org/licenses/MIT
+
+// Package gexf writes graphs from package graph in the GEXF format used
+// by Gephi.
+//
+// The format is described at https://gexf.net/.  Output is GEXF 1.3 with
+// the viz module used for node positions.
+//
+// Like package dot, gexf is a separate package from graph.  It imports graph;
+// graph knows nothing of gexf.  The scheme for optional arguments is also
+// that of package dot.  The Write and String functions take optional
+// arguments constructed by configuration functions of this package.
+//
+// All nodes of the graph are written, including isolated nodes.  Node IDs
+// are node numbers of the graph package.  Edge IDs number edges from 0 in
+// the order written.  Edges of undirected graphs are written with the lesser
+// node number as the source.
+package gexf
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strconv"
+
+	"github.com/soniakeys/graph"
+	"github.com/soniakeys/graph/internal/export"
+)
+
+// AttrVal represents a GEXF attribute title and value.
+type AttrVal = export.AttrVal
+
+// Config holds options that control the GEXF output.
+//
+// Generally you will not set members of a Config struct directly.  There is
+// an option function for each member.
+type Config = export.Config
+
+// Defaults holds a package default Config struct.
+//
+// Defaults is copied as the first configuration step.
+var Defaults = Config{}
+
+// Option is the type of optional arguments to Write and String.
+type Option = export.Option
+
+// EdgeAttr specifies a function to generate a list of edge attributes
+// given the arc label integers of the graph package.
+//
+// Edge attributes are written only for labeled graph types.  Attributes are
+// declared in the GEXF output with type string, in the order first returned.
+func EdgeAttr(f func(graph.LI) []AttrVal) Option { return export.EdgeAttr(f) }
+
+// EdgeLabel specifies a function to generate edge labels given the arc
+// label integers of the graph package.
+//
+// Edge labels are written only for labeled graph types.
+func EdgeLabel(f func(graph.LI) string) Option { return export.EdgeLabel(f) }
+
+// EdgeWeight specifies a weight function for writing edge weights.
+//
+// Edge weights are written only for labeled graph types.  NaN and infinite
+// weights are not valid GEXF weights and are omitted.
+func EdgeWeight(w graph.WeightFunc) Option { return export.EdgeWeight(w) }
+
+// NodeAttr specifies a function to generate a list of node attributes.
+//
+// Attributes are declared in the GEXF output with type string, in the order
+// first returned.
+func NodeAttr(f func(graph.NI) []AttrVal) Option { return export.NodeAttr(f) }
+
+// NodeLabel specifies a function to generate node labels.
+func NodeLabel(f func(graph.NI) string) Option { return export.NodeLabel(f) }
+
+// NodePos specifies node positions, as returned for example by
+// graph.Euclidean or graph.Geometric.
+//
+// The slice must have the length of the graph.  Positions are written with
+// the viz:position element.  Positions with a NaN or infinite coordinate are
+// omitted.
+func NodePos(pos []struct{ X, Y float64 }) Option { return export.NodePos(pos) }
+
+// String generates a GEXF format string for a graph.
+//
+// See Write for supported graph types.
+func String(g interface{}, options ...Option) (string, error) {
+	var b bytes.Buffer
+	if err := Write(g, &b, options...); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// Write writes GEXF format text for a graph to an io.Writer.
+//
+// g may be any of:
+//
+//	AdjacencyList
+//	Directed
+//	Undirected
+//	LabeledAdjacencyList
+//	LabeledDirected
+//	LabeledUndirected
+//
+// or a pointer to any of these types.  AdjacencyList and LabeledAdjacencyList
+// are written as directed graphs.  For undirected types, all arcs between
+// distinct nodes must occur in reciprocal pairs and each pair is written as
+// a single edge.
+func Write(g interface{}, w io.Writer, options ...Option) error {
+	gr, err := export.NewGraph("gexf", g)
+	if err != nil {
+		return err
+	}
+	cf, err := export.Configure("gexf", gr, Defaults, options)
+	if err != nil {
+		return err
+	}
+	b := bufio.NewWriter(w)
+	t := "directed"
+	if !gr.Directed {
+		t = "undirected"
+	}
+	b.WriteString(xml.Header)
+	b.WriteString(`<gexf xmlns="http://gexf.net/1.3" xmlns:viz="http://gexf.net/1.3/viz" version="1.3">
+  <graph defaultedgetype="` + t + `">
+`)
+	// declare attributes
+	var nodeAttr, edgeAttr []string
+	if cf.NodeAttr != nil {
+		for n := 0; n < gr.Order; n++ {
+			nodeAttr = titles(nodeAttr, cf.NodeAttr(graph.NI(n)))
+		}
+		writeDecl(b, "node", nodeAttr)
+	}
+	if cf.EdgeAttr != nil && gr.Labeled {
+		for _, e := range gr.Edges {
+			edgeAttr = titles(edgeAttr, cf.EdgeAttr(e.L))
+		}
+		writeDecl(b, "edge", edgeAttr)
+	}
+	b.WriteString("    <nodes>\n")
+	for n := 0; n < gr.Order; n++ {
+		ni := graph.NI(n)
+		b.WriteString(`      <node id="` + strconv.Itoa(n) + `"`)
+		if cf.NodeLabel != nil {
+			b.WriteString(` label="` + esc(cf.NodeLabel(ni)) + `"`)
+		}
+		var av []AttrVal
+		if cf.NodeAttr != nil {
+			av = cf.NodeAttr(ni)
+		}
+		var x, y string
+		pos := false
+		if cf.NodePos != nil {
+			p := cf.NodePos[n]
+			var okX, okY bool
+			x, okX = export.FmtFloat(p.X)
+			y, okY = export.FmtFloat(p.Y)
+			pos = okX && okY
+		}
+		if len(av) == 0 && !pos {
+			b.WriteString("/>\n")
+			continue
+		}
+		b.WriteString(">\n")
+		writeValues(b, nodeAttr, av)
+		if pos {
+			b.WriteString(`        <viz:position x="` + x +
+				`" y="` + y + `" z="0"/>` + "\n")
+		}
+		b.WriteString("      </node>\n")
+	}
+	b.WriteString("    </nodes>\n    <edges>\n")
+	for i, e := range gr.Edges {
+		b.WriteString(`      <edge id="` + strconv.Itoa(i) +
+			`" source="` + strconv.Itoa(int(e.Fr)) +
+			`" target="` + strconv.Itoa(int(e.To)) + `"`)
+		if gr.Labeled {
+			if cf.EdgeLabel != nil {
+				b.WriteString(` label="` + esc(cf.EdgeLabel(e.L)) + `"`)
+			}
+			if cf.EdgeWeight != nil {
+				if w, ok := export.FmtFloat(cf.EdgeWeight(e.L)); ok {
+					b.WriteString(` weight="` + w + `"`)
+				}
+			}
+		}
+		var av []AttrVal
+		if cf.EdgeAttr != nil && gr.Labeled {
+			av = cf.EdgeAttr(e.L)
+		}
+		if len(av) == 0 {
+			b.WriteString("/>\n")
+			continue
+		}
+		b.WriteString(">\n")
+		writeValues(b, edgeAttr, av)
+		b.WriteString("      </edge>\n")
+	}
+	if _, err := b.WriteString("    </edges>\n  </graph>\n</gexf>\n"); err != nil {
+		return err
+	}
+	return b.Flush()
+}
+
+// titles appends attribute titles of a not already in t.
+func titles(t []string, a []AttrVal) []string {
+next:
+	for _, av := range a {
+		for _, s := range t {
+			if s == av.Attr {
+				continue next
+			}
+		}
+		t = append(t, av.Attr)
+	}
+	return t
+}
+
+func writeDecl(b *bufio.Writer, class string, t []string) {
+	if len(t) == 0 {
+		return
+	}
+	b.WriteString(`    <attributes class="` + class + `">` + "\n")
+	for i, s := range t {
+		b.WriteString(`      <attribute id="` + strconv.Itoa(i) +
+			`" title="` + esc(s) + `" type="string"/>` + "\n")
+	}
+	b.WriteString("    </attributes>\n")
+}
+
+func writeValues(b *bufio.Writer, t []string, a []AttrVal) {
+	b.WriteString("        <attvalues>\n")
+	for _, av := range a {
+		for i, s := range t {
+			if s == av.Attr {
+				b.WriteString(`          <attvalue for="` + strconv.Itoa(i) +
+					`" value="` + esc(av.Val) + `"/>` + "\n")
+				break
+			}
+		}
+	}
+	b.WriteString("        </attvalues>\n")
+}
+
+func esc(s string) string {
+	var b bytes.Buffer
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
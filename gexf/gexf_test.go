@@ -0,0 +1,149 @@
+// Copyright 2026 Sonia Keys
+// License MIT: https://opensource.org/licenses/MIT
+
+package gexf_test
+
+import (
+	"encoding/xml"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/soniakeys/graph"
+	"github.com/soniakeys/graph/gexf"
+)
+
+func ExampleWrite() {
+	// 0---1
+	//  \ /
+	//   2
+	var g graph.Undirected
+	g.AddEdge(0, 1)
+	g.AddEdge(0, 2)
+	g.AddEdge(1, 2)
+	gexf.Write(g, os.Stdout)
+	// Output:
+	// <?xml version="1.0" encoding="UTF-8"?>
+	// <gexf xmlns="http://gexf.net/1.3" xmlns:viz="http://gexf.net/1.3/viz" version="1.3">
+	//   <graph defaultedgetype="undirected">
+	//     <nodes>
+	//       <node id="0"/>
+	//       <node id="1"/>
+	//       <node id="2"/>
+	//     </nodes>
+	//     <edges>
+	//       <edge id="0" source="0" target="1"/>
+	//       <edge id="1" source="0" target="2"/>
+	//       <edge id="2" source="1" target="2"/>
+	//     </edges>
+	//   </graph>
+	// </gexf>
+}
+
+func ExampleNodePos() {
+	g := graph.LabeledDirected{graph.LabeledAdjacencyList{
+		0: {{To: 1, Label: 0}},
+		1: nil,
+	}}
+	pos := []struct{ X, Y float64 }{{0, 0}, {1.5, 2}}
+	w := []float64{3.5}
+	gexf.Write(g, os.Stdout,
+		gexf.NodePos(pos),
+		gexf.NodeLabel(func(n graph.NI) string { return "n" + strconv.Itoa(int(n)) }),
+		gexf.NodeAttr(func(n graph.NI) []gexf.AttrVal {
+			return []gexf.AttrVal{{Attr: "color", Val: []string{"red", "blue"}[n]}}
+		}),
+		gexf.EdgeWeight(func(l graph.LI) float64 { return w[l] }),
+		gexf.EdgeAttr(func(l graph.LI) []gexf.AttrVal {
+			return []gexf.AttrVal{{Attr: "kind", Val: "road & rail"}}
+		}))
+	// Output:
+	// <?xml version="1.0" encoding="UTF-8"?>
+	// <gexf xmlns="http://gexf.net/1.3" xmlns:viz="http://gexf.net/1.3/viz" version="1.3">
+	//   <graph defaultedgetype="directed">
+	//     <attributes class="node">
+	//       <attribute id="0" title="color" type="string"/>
+	//     </attributes>
+	//     <attributes class="edge">
+	//       <attribute id="0" title="kind" type="string"/>
+	//     </attributes>
+	//     <nodes>
+	//       <node id="0" label="n0">
+	//         <attvalues>
+	//           <attvalue for="0" value="red"/>
+	//         </attvalues>
+	//         <viz:position x="0" y="0" z="0"/>
+	//       </node>
+	//       <node id="1" label="n1">
+	//         <attvalues>
+	//           <attvalue for="0" value="blue"/>
+	//         </attvalues>
+	//         <viz:position x="1.5" y="2" z="0"/>
+	//       </node>
+	//     </nodes>
+	//     <edges>
+	//       <edge id="0" source="0" target="1" weight="3.5">
+	//         <attvalues>
+	//           <attvalue for="0" value="road &amp; rail"/>
+	//         </attvalues>
+	//       </edge>
+	//     </edges>
+	//   </graph>
+	// </gexf>
+}
+
+func TestWellFormed(t *testing.T) {
+	g, pos, wt := graph.LabeledGeometric(50, .3, nil)
+	s, err := gexf.String(g, gexf.NodePos(pos),
+		gexf.EdgeWeight(func(l graph.LI) float64 { return wt[l] }),
+		gexf.EdgeLabel(func(l graph.LI) string { return `<"l">` }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		Nodes []struct{} `xml:"graph>nodes>node"`
+		Edges []struct{} `xml:"graph>edges>edge"`
+	}
+	if err := xml.Unmarshal([]byte(s), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if len(doc.Nodes) != g.Order() || len(doc.Edges) != g.Size() {
+		t.Fatal(len(doc.Nodes), "nodes", len(doc.Edges), "edges")
+	}
+}
+
+func TestErrors(t *testing.T) {
+	if _, err := gexf.String(graph.Undirected{graph.AdjacencyList{{1}, nil}}); err == nil {
+		t.Error("unpaired arc not detected")
+	}
+	if _, err := gexf.String(graph.Directed{graph.AdjacencyList{nil}},
+		gexf.NodePos(make([]struct{ X, Y float64 }, 2))); err == nil {
+		t.Error("position length not checked")
+	}
+	if _, err := gexf.String(3); err == nil {
+		t.Error("unknown type not detected")
+	}
+}
+
+func TestNonFinite(t *testing.T) {
+	g := graph.LabeledDirected{graph.LabeledAdjacencyList{
+		0: {{To: 1, Label: 0}},
+		1: {{To: 0, Label: 1}},
+	}}
+	w := []float64{math.NaN(), math.Inf(1)}
+	pos := []struct{ X, Y float64 }{{math.Inf(-1), 0}, {1, 2}}
+	s, err := gexf.String(g, gexf.NodePos(pos),
+		gexf.EdgeWeight(func(l graph.LI) float64 { return w[l] }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(s, "NaN") || strings.Contains(s, "Inf") ||
+		strings.Contains(s, "weight=") {
+		t.Fatal("non-finite value written:\n" + s)
+	}
+	if !strings.Contains(s, `<viz:position x="1" y="2" z="0"/>`) {
+		t.Fatal("finite position missing:\n" + s)
+	}
+}
@@ -26,6 +26,7 @@ import (
 //  Edge
 //  LabeledEdge
 //  WeightFunc
+//  Weight
 //  WeightedEdgeList
 //  TraverseOption
 
@@ -147,6 +148,18 @@ type LabeledEdge struct {
 // specific meaning other than physical weight.
 type WeightFunc func(label LI) (weight float64)
 
+// Weight is a type constraint for arc weights.
+//
+// Generic functions such as Dijkstra and Kruskal accept a weight function
+// returning any Weight type.  Integer weight types allow exact arithmetic.
+// Methods taking a WeightFunc are wrappers for the generic functions
+// instantiated with float64.
+type Weight interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
 // WeightedEdgeList is a graph representation.
 //
 // It is a labeled edge list, with an associated weight function to return
@@ -0,0 +1,416 @@
+// Copyright 2026 Sonia Keys
+// License MIT: https://opensource.org/licenses/MIT
+
+// Package graph6 encodes and decodes graphs in the graph6, sparse6, and
+// digraph6 formats used by nauty and other tools.
+//
+// The formats are described at
+// http://users.cecs.anu.edu.au/~bdm/data/formats.txt.  Each represents a
+// graph as a short string of printable ASCII.  Graph6 represents simple
+// undirected graphs, sparse6 represents undirected graphs that may have loops
+// and parallel edges, and digraph6 represents directed graphs that may have
+// loops but not parallel arcs.
+//
+// Like package dot, graph6 is a separate package from graph.  It imports
+// graph; graph knows nothing of graph6.
+//
+// Encode functions return strings without a header or trailing newline.
+// Decode functions accept the optional header, >>graph6<< for example,
+// and ignore a trailing newline.  The incremental sparse6 form, which
+// begins with ';', is not supported.
+//
+// Graphs returned by the decode functions have neighbor lists sorted in
+// ascending order.
+package graph6
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/soniakeys/graph"
+)
+
+// MaxOrder is the maximum graph order accepted by DecodeSparse6.
+//
+// Graph6 and digraph6 strings hold a bit for each pair of nodes and so
+// cannot declare an order out of proportion to their length.  Isolated
+// nodes take no bits of a sparse6 string though, so a short string can
+// declare a graph of any order.  DecodeSparse6 returns an error for an order
+// greater than MaxOrder rather than allocate a graph of that order.  Set
+// MaxOrder higher to decode larger graphs, or lower when decoding untrusted
+// input.
+var MaxOrder = 1 << 25
+
+// extend extends s as needed to include index n.
+func extend[S ~[]E, E any](s S, n graph.NI) S {
+	if int(n) >= len(s) {
+		s = append(s, make(S, int(n)+1-len(s))...)
+	}
+	return s
+}
+
+// encoder accumulates bits, six per byte.
+type encoder struct {
+	b    []byte
+	x    byte // pending bits
+	nBit uint // number of pending bits
+}
+
+// n appends the encoding N(n) of a graph order.
+func (e *encoder) n(n int) {
+	switch {
+	case n <= 62:
+		e.b = append(e.b, byte(n+63))
+	case n <= 258047:
+		e.b = append(e.b, 126)
+		e.bigEndian(uint64(n), 3)
+	default:
+		e.b = append(e.b, 126, 126)
+		e.bigEndian(uint64(n), 6)
+	}
+}
+
+// bigEndian appends nb bytes of six bits each.
+func (e *encoder) bigEndian(x uint64, nb int) {
+	for i := nb - 1; i >= 0; i-- {
+		e.b = append(e.b, byte(x>>uint(6*i)&63+63))
+	}
+}
+
+// bit appends a single bit to the bit vector.
+func (e *encoder) bit(b bool) {
+	e.x <<= 1
+	if b {
+		e.x |= 1
+	}
+	if e.nBit++; e.nBit == 6 {
+		e.b = append(e.b, e.x+63)
+		e.x, e.nBit = 0, 0
+	}
+}
+
+// bits appends the k low order bits of x, most significant first.
+func (e *encoder) bits(x, k uint) {
+	for i := k; i > 0; i-- {
+		e.bit(x>>(i-1)&1 == 1)
+	}
+}
+
+// pad completes the final byte with the bit p.
+func (e *encoder) pad(p bool) string {
+	for e.nBit > 0 {
+		e.bit(p)
+	}
+	return string(e.b)
+}
+
+// decoder extracts bits, six per byte.
+type decoder struct {
+	s    string
+	x    byte // bits of current byte
+	nBit uint // number of bits remaining in x
+}
+
+func newDecoder(s, header string) *decoder {
+	s = strings.TrimSuffix(s, "\n")
+	s = strings.TrimSuffix(s, "\r")
+	return &decoder{s: strings.TrimPrefix(s, header)}
+}
+
+func (d *decoder) byte6() (byte, error) {
+	if d.s == "" {
+		return 0, errors.New("graph6: unexpected end of string")
+	}
+	c := d.s[0]
+	if c < 63 || c > 126 {
+		return 0, fmt.Errorf("graph6: invalid character %q", c)
+	}
+	d.s = d.s[1:]
+	return c - 63, nil
+}
+
+// n decodes N(n).
+func (d *decoder) n() (int, error) {
+	c, err := d.byte6()
+	if err != nil || c < 63 {
+		return int(c), err
+	}
+	nb := 3
+	if len(d.s) > 0 && d.s[0] == 126 {
+		d.s = d.s[1:]
+		nb = 6
+	}
+	var n uint64
+	for i := 0; i < nb; i++ {
+		if c, err = d.byte6(); err != nil {
+			return 0, err
+		}
+		n = n<<6 | uint64(c)
+	}
+	if n > math.MaxInt32 {
+		return 0, fmt.Errorf("graph6: order %d too large", n)
+	}
+	return int(n), nil
+}
+
+// bit returns the next bit of the bit vector.  ok is false at the end
+// of the string.
+func (d *decoder) bit() (b, ok bool, err error) {
+	if d.nBit == 0 {
+		if d.s == "" {
+			return false, false, nil
+		}
+		if d.x, err = d.byte6(); err != nil {
+			return
+		}
+		d.nBit = 6
+	}
+	d.nBit--
+	return d.x>>d.nBit&1 == 1, true, nil
+}
+
+// bits returns the next k bits as an integer, most significant first.
+func (d *decoder) bits(k uint) (x uint, ok bool, err error) {
+	for i := uint(0); i < k; i++ {
+		var b bool
+		if b, ok, err = d.bit(); !ok || err != nil {
+			return
+		}
+		x <<= 1
+		if b {
+			x |= 1
+		}
+	}
+	return x, true, nil
+}
+
+// vectorLen checks that the remaining string holds exactly the number of
+// bytes needed for a bit vector of nBits bits.
+func (d *decoder) vectorLen(nBits int) error {
+	if want := (nBits + 5) / 6; len(d.s) != want {
+		return fmt.Errorf("graph6: bit vector has %d bytes, want %d",
+			len(d.s), want)
+	}
+	return nil
+}
+
+// EncodeGraph6 encodes a simple undirected graph in graph6 format.
+//
+// An error is returned if g has loops or parallel edges.
+func EncodeGraph6(g graph.Undirected) (string, error) {
+	a := g.AdjacencyList
+	n := len(a)
+	m := make([]bool, n*(n-1)/2+1)
+	// index of bit for edge i, j, where i < j
+	x := func(i, j int) int { return j*(j-1)/2 + i }
+	for fr, to := range a {
+		for _, to := range to {
+			switch i, j := int(to), fr; {
+			case i == j:
+				return "", fmt.Errorf("graph6: loop on node %d", fr)
+			case i < j:
+				if m[x(i, j)] {
+					return "", fmt.Errorf("graph6: parallel edges %d-%d", i, j)
+				}
+				m[x(i, j)] = true
+			}
+		}
+	}
+	var e encoder
+	e.n(n)
+	for _, b := range m[:n*(n-1)/2] {
+		e.bit(b)
+	}
+	return e.pad(false), nil
+}
+
+// DecodeGraph6 decodes a graph in graph6 format.
+func DecodeGraph6(s string) (graph.Undirected, error) {
+	d := newDecoder(s, ">>graph6<<")
+	n, err := d.n()
+	if err != nil {
+		return graph.Undirected{}, err
+	}
+	if err := d.vectorLen(n * (n - 1) / 2); err != nil {
+		return graph.Undirected{}, err
+	}
+	a := make(graph.AdjacencyList, n)
+	for j := 1; j < n; j++ {
+		for i := 0; i < j; i++ {
+			if b, _, err := d.bit(); err != nil {
+				return graph.Undirected{}, err
+			} else if b {
+				a[i] = append(a[i], graph.NI(j))
+				a[j] = append(a[j], graph.NI(i))
+			}
+		}
+	}
+	return graph.Undirected{a}, nil
+}
+
+// EncodeSparse6 encodes an undirected graph in sparse6 format.
+//
+// Loops and parallel edges are allowed.  A loop must be represented in g
+// by a single arc.
+func EncodeSparse6(g graph.Undirected) string {
+	a := g.AdjacencyList
+	n := len(a)
+	k := uint(0)
+	for x := n - 1; x > 0; x >>= 1 {
+		k++
+	}
+	var e encoder
+	e.b = append(e.b, ':')
+	e.n(n)
+	cur := 0
+	var us []int
+	for v, to := range a {
+		// edges u-v with u <= v, in order of u
+		us = us[:0]
+		for _, u := range to {
+			if int(u) <= v {
+				us = append(us, int(u))
+			}
+		}
+		sort.Ints(us)
+		for _, u := range us {
+			switch {
+			case v == cur:
+				e.bit(false)
+			case v == cur+1:
+				e.bit(true)
+			default:
+				e.bit(true)
+				e.bits(uint(v), k)
+				e.bit(false)
+			}
+			e.bits(uint(u), k)
+			cur = v
+		}
+	}
+	// pad with 1 bits, except the special case where that could be
+	// misread as an edge.
+	if k < 6 && n == 1<<k && cur == n-2 && e.nBit > 0 && 6-e.nBit > k {
+		e.bit(false)
+	}
+	return e.pad(true)
+}
+
+// DecodeSparse6 decodes a graph in sparse6 format.
+//
+// The order of the graph must not exceed MaxOrder.
+func DecodeSparse6(s string) (graph.Undirected, error) {
+	d := newDecoder(s, ">>sparse6<<")
+	switch {
+	case strings.HasPrefix(d.s, ";"):
+		return graph.Undirected{}, errors.New(
+			"graph6: incremental sparse6 not supported")
+	case !strings.HasPrefix(d.s, ":"):
+		return graph.Undirected{}, errors.New("graph6: missing ':'")
+	}
+	d.s = d.s[1:]
+	n, err := d.n()
+	if err != nil {
+		return graph.Undirected{}, err
+	}
+	if n > MaxOrder {
+		return graph.Undirected{}, fmt.Errorf(
+			"graph6: order %d exceeds MaxOrder %d", n, MaxOrder)
+	}
+	k := uint(0)
+	for x := n - 1; x > 0; x >>= 1 {
+		k++
+	}
+	// nodes are allocated as edges reference them, then extended to n
+	// after all edges are decoded.
+	var g graph.Undirected
+	v := uint(0)
+	for {
+		b, ok, err := d.bit()
+		if err != nil {
+			return graph.Undirected{}, err
+		}
+		if !ok {
+			break
+		}
+		x, ok, err := d.bits(k)
+		if err != nil {
+			return graph.Undirected{}, err
+		}
+		if !ok {
+			break
+		}
+		if b {
+			v++
+		}
+		if v >= uint(n) {
+			break
+		}
+		if x > v {
+			v = x
+		} else {
+			g.AdjacencyList = extend(g.AdjacencyList, graph.NI(v))
+			g.AddEdge(graph.NI(x), graph.NI(v))
+		}
+	}
+	g.AdjacencyList = extend(g.AdjacencyList, graph.NI(n-1))
+	for _, to := range g.AdjacencyList {
+		sort.Slice(to, func(i, j int) bool { return to[i] < to[j] })
+	}
+	return g, nil
+}
+
+// EncodeDigraph6 encodes a directed graph in digraph6 format.
+//
+// Loops are allowed.  An error is returned if g has parallel arcs.
+func EncodeDigraph6(g graph.Directed) (string, error) {
+	a := g.AdjacencyList
+	n := len(a)
+	m := make([]bool, n*n)
+	for fr, to := range a {
+		for _, to := range to {
+			x := fr*n + int(to)
+			if m[x] {
+				return "", fmt.Errorf("graph6: parallel arcs %d->%d", fr, to)
+			}
+			m[x] = true
+		}
+	}
+	var e encoder
+	e.b = append(e.b, '&')
+	e.n(n)
+	for _, b := range m {
+		e.bit(b)
+	}
+	return e.pad(false), nil
+}
+
+// DecodeDigraph6 decodes a graph in digraph6 format.
+func DecodeDigraph6(s string) (graph.Directed, error) {
+	d := newDecoder(s, ">>digraph6<<")
+	if !strings.HasPrefix(d.s, "&") {
+		return graph.Directed{}, errors.New("graph6: missing '&'")
+	}
+	d.s = d.s[1:]
+	n, err := d.n()
+	if err != nil {
+		return graph.Directed{}, err
+	}
+	if err := d.vectorLen(n * n); err != nil {
+		return graph.Directed{}, err
+	}
+	a := make(graph.AdjacencyList, n)
+	for fr := range a {
+		for to := 0; to < n; to++ {
+			if b, _, err := d.bit(); err != nil {
+				return graph.Directed{}, err
+			} else if b {
+				a[fr] = append(a[fr], graph.NI(to))
+			}
+		}
+	}
+	return graph.Directed{a}, nil
+}
@@ -0,0 +1,219 @@
+// Copyright 2026 Sonia Keys
+// License MIT: https://opensource.org/licenses/MIT
+
+package graph6_test
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/soniakeys/graph"
+	"github.com/soniakeys/graph/graph6"
+)
+
+func ExampleEncodeGraph6() {
+	// example from formats.txt
+	var g graph.Undirected
+	g.AddEdge(0, 2)
+	g.AddEdge(0, 4)
+	g.AddEdge(1, 3)
+	g.AddEdge(3, 4)
+	fmt.Println(graph6.EncodeGraph6(g))
+	// Output:
+	// DQc <nil>
+}
+
+func ExampleDecodeGraph6() {
+	g, err := graph6.DecodeGraph6(">>graph6<<DQc\n")
+	fmt.Println(err)
+	for fr, to := range g.AdjacencyList {
+		fmt.Println(fr, to)
+	}
+	// Output:
+	// <nil>
+	// 0 [2 4]
+	// 1 [3]
+	// 2 [0]
+	// 3 [1 4]
+	// 4 [0 3]
+}
+
+func ExampleEncodeSparse6() {
+	// example from formats.txt
+	var g graph.Undirected
+	g.AddEdge(0, 1)
+	g.AddEdge(0, 2)
+	g.AddEdge(1, 2)
+	g.AddEdge(5, 6)
+	fmt.Println(graph6.EncodeSparse6(g))
+	// Output:
+	// :Fa@x^
+}
+
+func ExampleDecodeSparse6() {
+	g, err := graph6.DecodeSparse6(":Fa@x^")
+	fmt.Println(err)
+	for fr, to := range g.AdjacencyList {
+		fmt.Println(fr, to)
+	}
+	// Output:
+	// <nil>
+	// 0 [1 2]
+	// 1 [0 2]
+	// 2 [0 1]
+	// 3 []
+	// 4 []
+	// 5 [6]
+	// 6 [5]
+}
+
+func ExampleEncodeDigraph6() {
+	// example from formats.txt
+	g := graph.Directed{graph.AdjacencyList{
+		0: {2, 4},
+		3: {1, 4},
+		4: nil,
+	}}
+	fmt.Println(graph6.EncodeDigraph6(g))
+	// Output:
+	// &DI?AO? <nil>
+}
+
+func ExampleDecodeDigraph6() {
+	g, err := graph6.DecodeDigraph6("&DI?AO?")
+	fmt.Println(err)
+	for fr, to := range g.AdjacencyList {
+		fmt.Println(fr, to)
+	}
+	// Output:
+	// <nil>
+	// 0 [2 4]
+	// 1 []
+	// 2 []
+	// 3 [1 4]
+	// 4 []
+}
+
+// sorted returns a copy of a with neighbor lists sorted, and nil and empty
+// lists normalized to empty.
+func sorted(a graph.AdjacencyList) graph.AdjacencyList {
+	s := make(graph.AdjacencyList, len(a))
+	for fr, to := range a {
+		t := append([]graph.NI{}, to...)
+		sort.Slice(t, func(i, j int) bool { return t[i] < t[j] })
+		s[fr] = t
+	}
+	return s
+}
+
+func TestRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(28))
+	for _, n := range []int{0, 1, 2, 4, 8, 16, 30, 63, 100} {
+		for i := 0; i < 10; i++ {
+			g, _ := graph.GnpUndirected(n, .3, r)
+			want := sorted(g.AdjacencyList)
+
+			s, err := graph6.EncodeGraph6(g)
+			if err != nil {
+				t.Fatal(err)
+			}
+			g6, err := graph6.DecodeGraph6(s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(sorted(g6.AdjacencyList), want) {
+				t.Fatalf("graph6 round trip mismatch, n=%d %s", n, s)
+			}
+
+			s = graph6.EncodeSparse6(g)
+			s6, err := graph6.DecodeSparse6(s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(sorted(s6.AdjacencyList), want) {
+				t.Fatalf("sparse6 round trip mismatch, n=%d %s", n, s)
+			}
+
+			d, _ := graph.GnpDirected(n, .3, r)
+			s, err = graph6.EncodeDigraph6(d)
+			if err != nil {
+				t.Fatal(err)
+			}
+			d6, err := graph6.DecodeDigraph6(s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(sorted(d6.AdjacencyList),
+				sorted(d.AdjacencyList)) {
+				t.Fatalf("digraph6 round trip mismatch, n=%d %s", n, s)
+			}
+		}
+	}
+}
+
+func TestSparse6Multigraph(t *testing.T) {
+	// loops and parallel edges, with n a power of 2 to exercise the
+	// special case of padding.
+	for _, n := range []int{2, 4, 8, 16} {
+		var g graph.Undirected
+		g.AddEdge(0, 0)
+		g.AddEdge(0, graph.NI(n-2))
+		g.AddEdge(0, graph.NI(n-2))
+		for len(g.AdjacencyList) < n {
+			g.AdjacencyList = append(g.AdjacencyList, nil)
+		}
+		s := graph6.EncodeSparse6(g)
+		d, err := graph6.DecodeSparse6(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(sorted(d.AdjacencyList), sorted(g.AdjacencyList)) {
+			t.Fatalf("n=%d %s: %v", n, s, d.AdjacencyList)
+		}
+	}
+}
+
+func TestErrors(t *testing.T) {
+	var g graph.Undirected
+	g.AddEdge(1, 1)
+	if _, err := graph6.EncodeGraph6(g); err == nil {
+		t.Error("graph6 loop not detected")
+	}
+	d := graph.Directed{graph.AdjacencyList{0: {1, 1}, 1: nil}}
+	if _, err := graph6.EncodeDigraph6(d); err == nil {
+		t.Error("digraph6 parallel arcs not detected")
+	}
+	for _, s := range []string{"", "DQ", "DQcc", "D Qc"} {
+		if _, err := graph6.DecodeGraph6(s); err == nil {
+			t.Errorf("no error for graph6 %q", s)
+		}
+	}
+	for _, s := range []string{"", "Fa@x^", ";Fa@x^"} {
+		if _, err := graph6.DecodeSparse6(s); err == nil {
+			t.Errorf("no error for sparse6 %q", s)
+		}
+	}
+	for _, s := range []string{"", "DI?AO?", "&DI?AO"} {
+		if _, err := graph6.DecodeDigraph6(s); err == nil {
+			t.Errorf("no error for digraph6 %q", s)
+		}
+	}
+	// a hostile sparse6 order, 2^31-1 from a few header bytes, must fail
+	// without attempting a large allocation.
+	if _, err := graph6.DecodeSparse6(":~~@~~~~~"); err == nil {
+		t.Error("hostile sparse6 order not detected")
+	}
+	defer func(m int) { graph6.MaxOrder = m }(graph6.MaxOrder)
+	graph6.MaxOrder = 10
+	if g, err := graph6.DecodeSparse6(graph6.EncodeSparse6(graph.Undirected{
+		make(graph.AdjacencyList, 10)})); err != nil || g.Order() != 10 {
+		t.Error("MaxOrder", g.Order(), err)
+	}
+	if _, err := graph6.DecodeSparse6(graph6.EncodeSparse6(graph.Undirected{
+		make(graph.AdjacencyList, 11)})); err == nil {
+		t.Error("MaxOrder exceeded not detected")
+	}
+}
@@ -0,0 +1,232 @@
+// Copyright 2026 Sonia Keys
+// License MIT: https://opensource.org/licenses/MIT
+
+package graph
+
+import "math"
+
+// HeavyLight is a heavy-light decomposition of a tree or forest supporting
+// queries on arc weights along tree paths.
+//
+// Each node is associated with the weight of the arc from its from-node.
+// Trees are decomposed into chains such that any tree path crosses O(log n)
+// chains.  Arc weights are stored in segment trees ordered by chain so that
+// path aggregate queries and weight updates take O(log² n) and O(log n)
+// time respectively.
+//
+// Construct with NewHeavyLight.
+type HeavyLight struct {
+	from  []NI
+	root  []NI  // root of the tree containing each node
+	depth []int // number of arcs from root
+	head  []NI  // top node of the chain containing each node
+	pos   []int // position of each node in chain order
+	node  []NI  // node at each position, the inverse of pos
+	sum   []float64
+	max   []float64
+}
+
+// NewHeavyLight builds a heavy-light decomposition from FromList f.
+//
+// Only the From members of f.Paths are used.  The FromList cannot be
+// cyclic.  It may represent a forest.
+//
+// Labels[n] must be the label of the arc from f.Paths[n].From to n, as
+// returned for example by LabeledDirected.FromListLabels.  Arc weights are
+// obtained from labels by w.
+func NewHeavyLight(f FromList, labels []LI, w WeightFunc) *HeavyLight {
+	p := f.Paths
+	n := len(p)
+	h := &HeavyLight{
+		from:  make([]NI, n),
+		root:  make([]NI, n),
+		depth: make([]int, n),
+		head:  make([]NI, n),
+		pos:   make([]int, n),
+		node:  make([]NI, 0, n),
+		sum:   make([]float64, 2*n),
+		max:   make([]float64, 2*n),
+	}
+	size := f.SubtreeSizes()
+	_, ch := f.postorder()
+	heavy := func(n NI) NI {
+		hv := NI(-1)
+		for _, c := range ch[n] {
+			if hv < 0 || size[c] > size[hv] {
+				hv = c
+			}
+		}
+		return hv
+	}
+	var heads []NI
+	for r, e := range p {
+		h.from[r] = e.From
+		if e.From >= 0 {
+			continue
+		}
+		h.root[r] = NI(r)
+		heads = append(heads[:0], NI(r))
+		for len(heads) > 0 {
+			hd := heads[len(heads)-1]
+			heads = heads[:len(heads)-1]
+			// walk down the heavy chain from hd
+			for c := hd; c >= 0; {
+				h.head[c] = hd
+				h.pos[c] = len(h.node)
+				h.node = append(h.node, c)
+				hv := heavy(c)
+				for _, to := range ch[c] {
+					h.root[to] = NI(r)
+					h.depth[to] = h.depth[c] + 1
+					if to != hv {
+						heads = append(heads, to)
+					}
+				}
+				c = hv
+			}
+		}
+	}
+	// segment trees, leaves at n+pos
+	for x, nd := range h.node {
+		wt := 0.
+		if p[nd].From >= 0 {
+			wt = w(labels[nd])
+		}
+		h.sum[n+x] = wt
+		h.max[n+x] = wt
+	}
+	for x := n - 1; x > 0; x-- {
+		h.pull(x)
+	}
+	return h
+}
+
+func (h *HeavyLight) pull(x int) {
+	h.sum[x] = h.sum[2*x] + h.sum[2*x+1]
+	h.max[x] = math.Max(h.max[2*x], h.max[2*x+1])
+}
+
+// query returns sum and max of leaves at positions l through r-1.
+func (h *HeavyLight) query(l, r int) (s, m float64) {
+	m = math.Inf(-1)
+	n := len(h.node)
+	for l, r = l+n, r+n; l < r; l, r = l/2, r/2 {
+		if l&1 == 1 {
+			s += h.sum[l]
+			m = math.Max(m, h.max[l])
+			l++
+		}
+		if r&1 == 1 {
+			r--
+			s += h.sum[r]
+			m = math.Max(m, h.max[r])
+		}
+	}
+	return
+}
+
+// SetWeight sets the weight of the arc from the from-node of n to n.
+//
+// The weight replaces the weight originally obtained from the WeightFunc.
+// Setting the weight of a root node has no effect on path queries.
+func (h *HeavyLight) SetWeight(n NI, wt float64) {
+	if h.from[n] < 0 {
+		return
+	}
+	x := len(h.node) + h.pos[n]
+	h.sum[x] = wt
+	h.max[x] = wt
+	for x /= 2; x > 0; x /= 2 {
+		h.pull(x)
+	}
+}
+
+// Weight returns the current weight of the arc from the from-node of n to n.
+//
+// The weight of a root node is 0.
+func (h *HeavyLight) Weight(n NI) float64 {
+	return h.sum[len(h.node)+h.pos[n]]
+}
+
+// PathSum returns the sum of arc weights along the tree path between nodes
+// a and b.
+//
+// If a and b are in different trees, PathSum returns ok = false.
+func (h *HeavyLight) PathSum(a, b NI) (sum float64, ok bool) {
+	sum, _, ok = h.aggregate(a, b)
+	return
+}
+
+// PathMax returns the maximum arc weight along the tree path between nodes
+// a and b.
+//
+// If a == b, the path has no arcs and PathMax returns -Inf.  If a and b are
+// in different trees, PathMax returns ok = false.
+func (h *HeavyLight) PathMax(a, b NI) (max float64, ok bool) {
+	_, max, ok = h.aggregate(a, b)
+	return
+}
+
+func (h *HeavyLight) aggregate(a, b NI) (s, m float64, ok bool) {
+	m = math.Inf(-1)
+	if h.root[a] != h.root[b] {
+		return 0, m, false
+	}
+	add := func(l, r int) {
+		qs, qm := h.query(l, r)
+		s += qs
+		m = math.Max(m, qm)
+	}
+	for h.head[a] != h.head[b] {
+		if h.depth[h.head[a]] < h.depth[h.head[b]] {
+			a, b = b, a
+		}
+		add(h.pos[h.head[a]], h.pos[a]+1)
+		a = h.from[h.head[a]]
+	}
+	if h.depth[a] > h.depth[b] {
+		a, b = b, a
+	}
+	// a is the common ancestor.  its own arc is not on the path.
+	add(h.pos[a]+1, h.pos[b]+1)
+	return s, m, true
+}
+
+// Path returns the list of nodes on the tree path from node a to node b.
+//
+// The first element is a and the last element is b.  Where a is a root node,
+// the result is the same as FromList.PathTo(b).  If a and b are in different
+// trees, Path returns nil.
+func (h *HeavyLight) Path(a, b NI) []NI {
+	if h.root[a] != h.root[b] {
+		return nil
+	}
+	var up, down []NI // nodes from a upward, nodes from b upward
+	for h.head[a] != h.head[b] {
+		if h.depth[h.head[a]] >= h.depth[h.head[b]] {
+			for x := h.pos[a]; x >= h.pos[h.head[a]]; x-- {
+				up = append(up, h.node[x])
+			}
+			a = h.from[h.head[a]]
+		} else {
+			for x := h.pos[b]; x >= h.pos[h.head[b]]; x-- {
+				down = append(down, h.node[x])
+			}
+			b = h.from[h.head[b]]
+		}
+	}
+	if h.pos[a] >= h.pos[b] {
+		for x := h.pos[a]; x >= h.pos[b]; x-- {
+			up = append(up, h.node[x])
+		}
+	} else {
+		for x := h.pos[b]; x > h.pos[a]; x-- {
+			down = append(down, h.node[x])
+		}
+		up = append(up, a)
+	}
+	for i := len(down) - 1; i >= 0; i-- {
+		up = append(up, down[i])
+	}
+	return up
+}
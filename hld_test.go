@@ -0,0 +1,108 @@
+// Copyright 2026 Sonia Keys
+// License MIT: https://opensource.org/licenses/MIT
+
+package graph_test
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/soniakeys/graph"
+)
+
+func ExampleHeavyLight() {
+	//       0
+	//    2 / \ 1
+	//     1   2
+	//  3 / \ 4 \ 5
+	//   3   4   5
+	g := graph.LabeledDirected{graph.LabeledAdjacencyList{
+		0: {{To: 1, Label: 2}, {To: 2, Label: 1}},
+		1: {{To: 3, Label: 3}, {To: 4, Label: 4}},
+		2: {{To: 5, Label: 5}},
+		5: {},
+	}}
+	f, labels, _ := g.FromListLabels()
+	h := graph.NewHeavyLight(*f, labels,
+		func(l graph.LI) float64 { return float64(l) })
+	fmt.Println(h.Path(3, 5))
+	fmt.Println(h.PathSum(3, 5))
+	fmt.Println(h.PathMax(3, 5))
+	h.SetWeight(2, 10)
+	fmt.Println(h.PathMax(3, 5))
+	// Output:
+	// [3 1 0 2 5]
+	// 11 true
+	// 5 true
+	// 10 true
+}
+
+func TestHeavyLight(t *testing.T) {
+	r := rand.New(rand.NewSource(43))
+	const n = 200
+	f := graph.NewFromList(n)
+	labels := make([]graph.LI, n)
+	w := make([]float64, n)
+	for i := range f.Paths {
+		f.Paths[i].From = -1
+		if i > 0 && r.Intn(20) > 0 {
+			f.Paths[i].From = graph.NI(r.Intn(i))
+		}
+		labels[i] = graph.LI(i)
+		w[i] = float64(r.Intn(100))
+	}
+	f.RecalcLeaves()
+	f.RecalcLen()
+	h := graph.NewHeavyLight(f, labels, func(l graph.LI) float64 { return w[l] })
+	x := graph.NewLCAIndex(f, nil, nil)
+	for i := 0; i < 2000; i++ {
+		if i%10 == 0 {
+			v := graph.NI(r.Intn(n))
+			if f.Paths[v].From >= 0 {
+				w[v] = float64(r.Intn(100))
+				h.SetWeight(v, w[v])
+			}
+		}
+		a := graph.NI(r.Intn(n))
+		b := graph.NI(r.Intn(n))
+		c := x.LCA(a, b)
+		// brute force
+		var up, down []graph.NI
+		s, m := 0., math.Inf(-1)
+		for v := a; v != c; v = f.Paths[v].From {
+			up = append(up, v)
+			s += w[v]
+			m = math.Max(m, w[v])
+		}
+		for v := b; v != c; v = f.Paths[v].From {
+			down = append(down, v)
+			s += w[v]
+			m = math.Max(m, w[v])
+		}
+		gs, ok := h.PathSum(a, b)
+		gm, _ := h.PathMax(a, b)
+		p := h.Path(a, b)
+		if c < 0 {
+			if ok || p != nil {
+				t.Fatal("different trees", a, b)
+			}
+			continue
+		}
+		up = append(up, c)
+		for j := len(down) - 1; j >= 0; j-- {
+			up = append(up, down[j])
+		}
+		if !ok || gs != s || gm != m {
+			t.Fatal("aggregate", a, b, gs, s, gm, m)
+		}
+		if !reflect.DeepEqual(p, up) {
+			t.Fatal("Path", a, b, p, up)
+		}
+		if root := f.Root(b); !reflect.DeepEqual(h.Path(root, b), f.PathTo(b, nil)) {
+			t.Fatal("PathTo", b)
+		}
+	}
+}
@@ -0,0 +1,225 @@
+// Copyright 2026 Sonia Keys
+// License MIT: https://opensource.org/licenses/MIT
+
+package graph
+
+// implicit.go -- search on graphs not materialized as adjacency lists.
+//
+// Most algorithms of this package work directly on concrete slice types.
+// The functions here instead accept any type implementing Neighborer, so
+// they can search graphs where arcs are generated lazily, such as grid
+// worlds or puzzle state spaces.  Nodes can be any comparable type.
+
+import (
+	"container/heap"
+	"iter"
+)
+
+// Neighborer is implemented by graphs that can enumerate arcs from a node.
+//
+// Neighbors returns an iterator over the arcs from node n, yielding the
+// to-node and label of each arc.  The iterator must stop if yield returns
+// false.
+//
+// AdjacencyList and LabeledAdjacencyList, and so Directed, Undirected,
+// LabeledDirected, and LabeledUndirected, implement Neighborer[NI].
+type Neighborer[N comparable] interface {
+	Neighbors(n N) iter.Seq2[N, LI]
+}
+
+// Neighbors returns an iterator over arcs from node n.
+//
+// Arcs are unlabeled so each arc is yielded with label -1.  The method
+// implements Neighborer[NI].
+func (g AdjacencyList) Neighbors(n NI) iter.Seq2[NI, LI] {
+	return func(yield func(NI, LI) bool) {
+		for _, to := range g[n] {
+			if !yield(to, -1) {
+				return
+			}
+		}
+	}
+}
+
+// Neighbors returns an iterator over arcs from node n, yielding the
+// to-node and label of each arc.
+//
+// The method implements Neighborer[NI].
+func (g LabeledAdjacencyList) Neighbors(n NI) iter.Seq2[NI, LI] {
+	return func(yield func(NI, LI) bool) {
+		for _, to := range g[n] {
+			if !yield(to.To, to.Label) {
+				return
+			}
+		}
+	}
+}
+
+// FromMap encodes a spanning forest of reached nodes, for graphs with any
+// comparable node type.
+//
+// It is analogous to FromList, but as nodes of an implicit graph are not
+// slice indexes, it is a map.  Nodes not reached are absent from the map.
+type FromMap[N comparable] map[N]MapPathEnd[N]
+
+// MapPathEnd is an element of a FromMap.
+//
+// Len is the number of nodes in the path from the root to the node.  It is
+// 1 for a root node, in which case From and Label are not meaningful.
+// Otherwise From is the previous node on the path and Label is the label
+// of the arc from From to the node.
+type MapPathEnd[N comparable] struct {
+	From  N
+	Label LI
+	Len   int
+}
+
+// PathTo decodes a FromMap, recovering the path from the root to node n.
+//
+// The path is returned as a list of nodes from the root to n.  If n is not
+// in the map, PathTo returns nil.
+func (f FromMap[N]) PathTo(n N) []N {
+	e, ok := f[n]
+	if !ok {
+		return nil
+	}
+	p := make([]N, e.Len)
+	for i := e.Len - 1; ; i-- {
+		p[i] = n
+		if i == 0 {
+			return p
+		}
+		n = e.From
+		e = f[n]
+	}
+}
+
+// BreadthFirstImplicit traverses a graph in breadth first order.
+//
+// Function visit is called for each node as it is reached, in breadth first
+// order starting with node start.  When visit is called the FromMap entry
+// for the node is already recorded and can be consulted with the argument
+// f.  As long as visit returns true the traversal continues.  If it returns
+// false the traversal terminates.  For infinite graphs, visit must return
+// false at some point.  Argument visit can be nil to traverse all nodes
+// reachable from start.
+//
+// The FromMap of reached nodes is returned.
+func BreadthFirstImplicit[N comparable](g Neighborer[N], start N, visit func(n N, f FromMap[N]) bool) (f FromMap[N]) {
+	f = FromMap[N]{start: {Len: 1}}
+	if visit != nil && !visit(start, f) {
+		return
+	}
+	frontier := []N{start}
+	for len(frontier) > 0 {
+		var next []N
+		for _, n := range frontier {
+			nextLen := f[n].Len + 1
+			for to, l := range g.Neighbors(n) {
+				if _, ok := f[to]; ok {
+					continue
+				}
+				f[to] = MapPathEnd[N]{From: n, Label: l, Len: nextLen}
+				if visit != nil && !visit(to, f) {
+					return
+				}
+				next = append(next, to)
+			}
+		}
+		frontier = next
+	}
+	return
+}
+
+// DijkstraImplicit finds a shortest path by Dijkstra's algorithm.
+//
+// Arc weights are obtained from arc labels by w and must be non-negative.
+// Where multiple paths exist with the same distance, a path with the minimum
+// number of nodes is returned.  The search stops when a shortest path to end
+// is found.  If the graph is infinite and end is not reachable the search
+// will not terminate.
+//
+// If a path is found, DijkstraImplicit returns a FromMap encoding the path,
+// the path distance, and ok = true.  The FromMap also contains nodes reached
+// during the search.  Otherwise it returns ok = false.
+//
+// See also LabeledAdjacencyList.Dijkstra for a version on a materialized
+// graph.
+func DijkstraImplicit[N comparable](g Neighborer[N], start, end N, w WeightFunc) (f FromMap[N], dist float64, ok bool) {
+	return AStarAImplicit(g, start, end, w, func(N) float64 { return 0 })
+}
+
+// AStarAImplicit finds a path between two nodes by algorithm A or A*.
+//
+// Heuristic h is an estimate of the distance from a node to node end, as
+// with type Heuristic.  Other arguments and return values are as for
+// DijkstraImplicit.
+//
+// See also LabeledAdjacencyList.AStarA for a version on a materialized
+// graph and documentation on heuristic functions.
+func AStarAImplicit[N comparable](g Neighborer[N], start, end N, w WeightFunc, h func(N) float64) (f FromMap[N], dist float64, ok bool) {
+	f = FromMap[N]{start: {Len: 1}}
+	r := map[N]*implicitNode[N]{}
+	cr := &implicitNode[N]{n: start, f: h(start), fx: -1}
+	r[start] = cr
+	oh := implicitHeap[N]{}
+	heap.Push(&oh, cr)
+	for len(oh) > 0 {
+		best := heap.Pop(&oh).(*implicitNode[N])
+		if best.n == end {
+			return f, best.d, true
+		}
+		nextLen := f[best.n].Len + 1
+		for to, l := range g.Neighbors(best.n) {
+			d := best.d + w(l)
+			alt, reached := r[to]
+			if reached {
+				if d > alt.d || d == alt.d && nextLen >= f[to].Len {
+					continue
+				}
+			} else {
+				alt = &implicitNode[N]{n: to, fx: -1}
+				r[to] = alt
+			}
+			f[to] = MapPathEnd[N]{From: best.n, Label: l, Len: nextLen}
+			alt.d = d
+			alt.f = d + h(to)
+			if alt.fx < 0 {
+				heap.Push(&oh, alt)
+			} else {
+				heap.Fix(&oh, alt.fx)
+			}
+		}
+	}
+	return f, 0, false
+}
+
+// implicitNode holds data for a node reached by AStarAImplicit.
+type implicitNode[N comparable] struct {
+	n  N
+	d  float64 // path distance from start
+	f  float64 // d + heuristic estimate
+	fx int     // heap.Fix index, -1 when not on heap
+}
+
+type implicitHeap[N comparable] []*implicitNode[N]
+
+func (h implicitHeap[N]) Len() int           { return len(h) }
+func (h implicitHeap[N]) Less(i, j int) bool { return h[i].f < h[j].f }
+func (h implicitHeap[N]) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].fx = i
+	h[j].fx = j
+}
+func (p *implicitHeap[N]) Push(x interface{}) {
+	nd := x.(*implicitNode[N])
+	nd.fx = len(*p)
+	*p = append(*p, nd)
+}
+func (p *implicitHeap[N]) Pop() interface{} {
+	h := *p
+	last := len(h) - 1
+	*p = h[:last]
+	h[last].fx = -1
+	return h[last]
+}
@@ -0,0 +1,130 @@
+// Copyright 2026 Sonia Keys
+// License MIT: https://opensource.org/licenses/MIT
+
+package graph_test
+
+import (
+	"fmt"
+	"iter"
+	"math/rand"
+	"testing"
+
+	"github.com/soniakeys/graph"
+)
+
+// grid is an implicit graph of an unbounded grid world with walls.
+type grid struct {
+	walls map[[2]int]bool
+}
+
+// Neighbors yields adjacent open cells.  Labels index moves.
+func (g grid) Neighbors(n [2]int) iter.Seq2[[2]int, graph.LI] {
+	return func(yield func([2]int, graph.LI) bool) {
+		for l, m := range [][2]int{{1, 0}, {0, 1}, {-1, 0}, {0, -1}} {
+			to := [2]int{n[0] + m[0], n[1] + m[1]}
+			if to[0] < 0 || to[1] < 0 || to[0] > 4 || to[1] > 4 || g.walls[to] {
+				continue
+			}
+			if !yield(to, graph.LI(l)) {
+				return
+			}
+		}
+	}
+}
+
+func ExampleAStarAImplicit() {
+	// ..#..
+	// ..#..
+	// ..#..
+	// .....
+	// .....
+	g := grid{map[[2]int]bool{{2, 0}: true, {2, 1}: true, {2, 2}: true}}
+	w := func(graph.LI) float64 { return 1 }
+	end := [2]int{4, 0}
+	h := func(n [2]int) float64 {
+		dx, dy := end[0]-n[0], end[1]-n[1]
+		if dx < 0 {
+			dx = -dx
+		}
+		if dy < 0 {
+			dy = -dy
+		}
+		return float64(dx + dy)
+	}
+	f, dist, ok := graph.AStarAImplicit[[2]int](g, [2]int{0, 0}, end, w, h)
+	fmt.Println(ok, dist)
+	fmt.Println(f.PathTo(end))
+	// Output:
+	// true 10
+	// [[0 0] [1 0] [1 1] [1 2] [1 3] [2 3] [3 3] [4 3] [4 2] [4 1] [4 0]]
+}
+
+func ExampleBreadthFirstImplicit() {
+	g := grid{}
+	// stop at the first node at distance 3
+	var last [2]int
+	graph.BreadthFirstImplicit[[2]int](g, [2]int{0, 0},
+		func(n [2]int, f graph.FromMap[[2]int]) bool {
+			last = n
+			return f[n].Len < 4
+		})
+	fmt.Println(last)
+	// Output:
+	// [3 0]
+}
+
+func ExampleDijkstraImplicit() {
+	// existing graph types satisfy Neighborer
+	g := graph.LabeledDirected{graph.LabeledAdjacencyList{
+		0: {{To: 1, Label: 7}, {To: 2, Label: 2}},
+		2: {{To: 1, Label: 3}},
+		1: {},
+	}}
+	w := func(l graph.LI) float64 { return float64(l) }
+	f, dist, ok := graph.DijkstraImplicit[graph.NI](g, 0, 1, w)
+	fmt.Println(ok, dist, f.PathTo(1))
+	// Output:
+	// true 5 [0 2 1]
+}
+
+func TestImplicit(t *testing.T) {
+	r := rand.New(rand.NewSource(40))
+	const n = 80
+	g := graph.LabeledDirected{make(graph.LabeledAdjacencyList, n)}
+	var w []float64
+	for i := 0; i < 250; i++ {
+		fr := r.Intn(n)
+		g.LabeledAdjacencyList[fr] = append(g.LabeledAdjacencyList[fr],
+			graph.Half{To: graph.NI(r.Intn(n)), Label: graph.LI(len(w))})
+		w = append(w, float64(r.Intn(10)))
+	}
+	wf := func(l graph.LI) float64 { return w[l] }
+	_, dist, _ := g.Dijkstra(0, -1, wf)
+	// unit weights give breadth first path lengths
+	bf, _, _ := g.Dijkstra(0, -1, func(graph.LI) float64 { return 1 })
+	fm := graph.BreadthFirstImplicit[graph.NI](g.LabeledAdjacencyList.Unlabeled(), 0, nil)
+	for end := graph.NI(0); end < n; end++ {
+		if (bf.Paths[end].Len > 0) != (fm[end].Len > 0) ||
+			bf.Paths[end].Len != fm[end].Len {
+			t.Fatal("BreadthFirstImplicit", end)
+		}
+		f, d, ok := graph.DijkstraImplicit[graph.NI](g, 0, end, wf)
+		if ok != (bf.Paths[end].Len > 0) {
+			t.Fatal("DijkstraImplicit reach", end)
+		}
+		if !ok {
+			continue
+		}
+		if d != dist[end] {
+			t.Fatal("DijkstraImplicit dist", end, d, dist[end])
+		}
+		// path distance must match
+		s := 0.
+		for _, fr := range f.PathTo(end)[1:] {
+			s += w[f[fr].Label]
+		}
+		if s != d {
+			t.Fatal("DijkstraImplicit path", end)
+		}
+	}
+}
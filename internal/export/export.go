@@ -0,0 +1,184 @@
+// Copyright 2026 Sonia Keys
+// License MIT: https://opensource.org/licenses/MIT
+
+// Package export holds code common to the graph export packages gexf and
+// cytoscape:  the configuration scheme and the reduction of graph types to
+// edge lists.
+package export
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/soniakeys/graph"
+)
+
+// AttrVal represents an attribute name and value.
+type AttrVal struct {
+	Attr string
+	Val  string
+}
+
+// Config holds options that control export output.
+type Config struct {
+	EdgeAttr   func(graph.LI) []AttrVal
+	EdgeLabel  func(graph.LI) string
+	EdgeWeight graph.WeightFunc
+	NodeAttr   func(graph.NI) []AttrVal
+	NodeLabel  func(graph.NI) string
+	NodePos    []struct{ X, Y float64 }
+}
+
+// Option is the type of optional arguments to export functions.
+type Option func(*Config)
+
+// EdgeAttr returns an Option setting Config.EdgeAttr.
+func EdgeAttr(f func(graph.LI) []AttrVal) Option {
+	return func(c *Config) { c.EdgeAttr = f }
+}
+
+// EdgeLabel returns an Option setting Config.EdgeLabel.
+func EdgeLabel(f func(graph.LI) string) Option {
+	return func(c *Config) { c.EdgeLabel = f }
+}
+
+// EdgeWeight returns an Option setting Config.EdgeWeight.
+func EdgeWeight(w graph.WeightFunc) Option {
+	return func(c *Config) { c.EdgeWeight = w }
+}
+
+// NodeAttr returns an Option setting Config.NodeAttr.
+func NodeAttr(f func(graph.NI) []AttrVal) Option {
+	return func(c *Config) { c.NodeAttr = f }
+}
+
+// NodeLabel returns an Option setting Config.NodeLabel.
+func NodeLabel(f func(graph.NI) string) Option {
+	return func(c *Config) { c.NodeLabel = f }
+}
+
+// NodePos returns an Option setting Config.NodePos.
+func NodePos(pos []struct{ X, Y float64 }) Option {
+	return func(c *Config) { c.NodePos = pos }
+}
+
+// Configure copies defaults, applies options, and validates the result for
+// graph g.  Argument pkg prefixes error messages.
+func Configure(pkg string, g *Graph, defaults Config, options []Option) (Config, error) {
+	cf := defaults
+	for _, o := range options {
+		o(&cf)
+	}
+	if cf.NodePos != nil && len(cf.NodePos) != g.Order {
+		return cf, fmt.Errorf("%s: %d positions for graph of order %d",
+			pkg, len(cf.NodePos), g.Order)
+	}
+	return cf, nil
+}
+
+// FmtFloat formats a finite float with the shortest representation that
+// round trips.  For NaN and infinities, which neither XML Schema floats nor
+// JSON numbers represent, it returns ok = false and the value should be
+// omitted from the output.
+func FmtFloat(f float64) (s string, ok bool) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return "", false
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64), true
+}
+
+// Edge is an edge or arc to be written.
+type Edge struct {
+	Fr, To graph.NI
+	L      graph.LI
+}
+
+// Graph is a graph to be written, reduced to a list of edges.
+type Graph struct {
+	Order    int
+	Directed bool
+	Labeled  bool
+	Edges    []Edge
+}
+
+// NewGraph reduces g, which may be any of the adjacency list types of
+// package graph or a pointer to one, to a Graph.
+//
+// AdjacencyList and LabeledAdjacencyList are taken as directed.  For
+// undirected types, all arcs between distinct nodes must occur in
+// reciprocal pairs and each pair gives a single edge, with the lesser node
+// number first.  Argument pkg prefixes error messages.
+func NewGraph(pkg string, g interface{}) (*Graph, error) {
+	switch t := g.(type) {
+	case graph.AdjacencyList:
+		return unlabeled(pkg, t, true)
+	case *graph.AdjacencyList:
+		return unlabeled(pkg, *t, true)
+	case graph.Directed:
+		return unlabeled(pkg, t.AdjacencyList, true)
+	case *graph.Directed:
+		return unlabeled(pkg, t.AdjacencyList, true)
+	case graph.Undirected:
+		return unlabeled(pkg, t.AdjacencyList, false)
+	case *graph.Undirected:
+		return unlabeled(pkg, t.AdjacencyList, false)
+	case graph.LabeledAdjacencyList:
+		return labeled(pkg, t, true)
+	case *graph.LabeledAdjacencyList:
+		return labeled(pkg, *t, true)
+	case graph.LabeledDirected:
+		return labeled(pkg, t.LabeledAdjacencyList, true)
+	case *graph.LabeledDirected:
+		return labeled(pkg, t.LabeledAdjacencyList, true)
+	case graph.LabeledUndirected:
+		return labeled(pkg, t.LabeledAdjacencyList, false)
+	case *graph.LabeledUndirected:
+		return labeled(pkg, t.LabeledAdjacencyList, false)
+	}
+	return nil, fmt.Errorf("%s: unknown graph type", pkg)
+}
+
+func unlabeled(pkg string, g graph.AdjacencyList, directed bool) (*Graph, error) {
+	r := &Graph{Order: len(g), Directed: directed}
+	if directed {
+		for fr, to := range g {
+			for _, to := range to {
+				r.Edges = append(r.Edges, Edge{graph.NI(fr), to, -1})
+			}
+		}
+		return r, nil
+	}
+	if u, _, _ := g.IsUndirected(); !u {
+		return nil, fmt.Errorf("%s: directed graph", pkg)
+	}
+	graph.Undirected{AdjacencyList: g}.Edges(func(e graph.Edge) {
+		if e.N1 > e.N2 {
+			e.N1, e.N2 = e.N2, e.N1
+		}
+		r.Edges = append(r.Edges, Edge{e.N1, e.N2, -1})
+	})
+	return r, nil
+}
+
+func labeled(pkg string, g graph.LabeledAdjacencyList, directed bool) (*Graph, error) {
+	r := &Graph{Order: len(g), Directed: directed, Labeled: true}
+	if directed {
+		for fr, to := range g {
+			for _, to := range to {
+				r.Edges = append(r.Edges, Edge{graph.NI(fr), to.To, to.Label})
+			}
+		}
+		return r, nil
+	}
+	if u, _, _ := g.IsUndirected(); !u {
+		return nil, fmt.Errorf("%s: directed graph", pkg)
+	}
+	graph.LabeledUndirected{LabeledAdjacencyList: g}.Edges(func(e graph.LabeledEdge) {
+		if e.N1 > e.N2 {
+			e.N1, e.N2 = e.N2, e.N1
+		}
+		r.Edges = append(r.Edges, Edge{e.N1, e.N2, e.LI})
+	})
+	return r, nil
+}
@@ -0,0 +1,486 @@
+// Copyright 2026 Sonia Keys
+// License MIT: https://opensource.org/licenses/MIT
+
+package graph
+
+// json.go has MarshalJSON and UnmarshalJSON methods for graph types.
+//
+// Without these methods, encoding/json would encode graphs by their
+// underlying representations, for example nested arrays for AdjacencyList
+// and an opaque bits.Bits value for FromList.Leaves.  The methods here
+// instead implement a documented schema.  See LabelTables.
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// LabelTables holds optional label tables of the JSON graph schema.
+//
+// The JSON schema for graphs is an object with the members
+//
+//	directed     true for Directed and LabeledDirected,
+//	             false for Undirected and LabeledUndirected.
+//	adjacency    an array with an element for each node.  For unlabeled
+//	             graphs each element is an array of to nodes.  For labeled
+//	             graphs each element is an array of [to, label] pairs.
+//	nodeLabels   optional array of strings, one for each node.
+//	edgeLabels   optional array of strings, indexed by arc label.
+//
+// For example the labeled directed graph
+//
+//	0: {{1 0} {2 1}}
+//	1: {{2 0}}
+//	2: {}
+//
+// with node labels a, b, c and edge labels x, y, is encoded
+//
+//	{"directed":true,"adjacency":[[[1,0],[2,1]],[[2,0]],[]],
+//	 "nodeLabels":["a","b","c"],"edgeLabels":["x","y"]}
+//
+// The MarshalJSON methods do not write label tables.  Methods
+// MarshalJSONTables and UnmarshalJSONTables write and read them.
+type LabelTables struct {
+	Nodes []string // node labels, indexed by NI
+	Edges []string // arc or edge labels, indexed by LI
+}
+
+// jsonGraph is the JSON schema for graph types.
+type jsonGraph struct {
+	Directed   *bool           `json:"directed"`
+	Adjacency  json.RawMessage `json:"adjacency"`
+	NodeLabels []string        `json:"nodeLabels,omitempty"`
+	EdgeLabels []string        `json:"edgeLabels,omitempty"`
+}
+
+// marshalGraph marshals a graph given adjacency in the form of the schema.
+func marshalGraph(directed bool, order int, adj interface{}, t LabelTables) ([]byte, error) {
+	if t.Nodes != nil && len(t.Nodes) != order {
+		return nil, fmt.Errorf("%d node labels for graph of order %d",
+			len(t.Nodes), order)
+	}
+	a, err := json.Marshal(adj)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(jsonGraph{&directed, a, t.Nodes, t.Edges})
+}
+
+// unmarshalGraph unmarshals data into adj, returning label tables.
+func unmarshalGraph(data []byte, directed bool, adj interface{}) (t LabelTables, err error) {
+	var j jsonGraph
+	if err = json.Unmarshal(data, &j); err != nil {
+		return
+	}
+	switch {
+	case j.Directed == nil:
+		return t, errors.New("JSON graph missing directed flag")
+	case *j.Directed != directed:
+		return t, fmt.Errorf("JSON graph has directed %t, expected %t",
+			*j.Directed, directed)
+	case j.Adjacency == nil:
+		return t, errors.New("JSON graph missing adjacency")
+	}
+	if err = json.Unmarshal(j.Adjacency, adj); err != nil {
+		return
+	}
+	return LabelTables{j.NodeLabels, j.EdgeLabels}, nil
+}
+
+// jsonAdj returns g with nil arc lists replaced by empty lists, so they
+// encode as empty arrays rather than null.
+func (g AdjacencyList) jsonAdj() [][]NI {
+	a := make([][]NI, len(g))
+	for fr, to := range g {
+		if to == nil {
+			to = []NI{}
+		}
+		a[fr] = to
+	}
+	return a
+}
+
+// jsonAdj returns g with arcs as [to, label] pairs.  It also checks that
+// labels are in range of edge labels, if present.
+func (g LabeledAdjacencyList) jsonAdj(edgeLabels []string) ([][][2]int32, error) {
+	a := make([][][2]int32, len(g))
+	for fr, to := range g {
+		p := make([][2]int32, len(to))
+		for i, h := range to {
+			if edgeLabels != nil &&
+				(h.Label < 0 || int(h.Label) >= len(edgeLabels)) {
+				return nil, fmt.Errorf("arc %d->%d label %d has no edge label",
+					fr, h.To, h.Label)
+			}
+			p[i] = [2]int32{int32(h.To), int32(h.Label)}
+		}
+		a[fr] = p
+	}
+	return a, nil
+}
+
+// fromJSONAdj converts [to, label] pairs to a LabeledAdjacencyList,
+// validating arcs and labels.
+func fromJSONAdj(a [][][2]int32, t LabelTables) (LabeledAdjacencyList, error) {
+	g := make(LabeledAdjacencyList, len(a))
+	for fr, p := range a {
+		if p == nil {
+			continue
+		}
+		to := make([]Half, len(p))
+		for i, h := range p {
+			to[i] = Half{NI(h[0]), LI(h[1])}
+		}
+		g[fr] = to
+	}
+	if ok, fr, to := g.BoundsOk(); !ok {
+		return nil, fmt.Errorf("arc %d->%d out of bounds", fr, to.To)
+	}
+	if t.Nodes != nil && len(t.Nodes) != len(g) {
+		return nil, fmt.Errorf("%d node labels for graph of order %d",
+			len(t.Nodes), len(g))
+	}
+	if t.Edges != nil {
+		if _, err := g.jsonAdj(t.Edges); err != nil {
+			return nil, err
+		}
+	}
+	return g, nil
+}
+
+// validateJSON validates a decoded AdjacencyList.
+func (g AdjacencyList) validateJSON(t LabelTables) error {
+	if ok, fr, to := g.BoundsOk(); !ok {
+		return fmt.Errorf("arc %d->%d out of bounds", fr, to)
+	}
+	if t.Nodes != nil && len(t.Nodes) != len(g) {
+		return fmt.Errorf("%d node labels for graph of order %d",
+			len(t.Nodes), len(g))
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+//
+// See LabelTables for the JSON schema.
+func (g Directed) MarshalJSON() ([]byte, error) {
+	return g.MarshalJSONTables(LabelTables{})
+}
+
+// MarshalJSONTables marshals g with label tables.
+//
+// Table t.Nodes, if non-nil, must have the length of g.  See LabelTables
+// for the JSON schema.
+func (g Directed) MarshalJSONTables(t LabelTables) ([]byte, error) {
+	return marshalGraph(true, g.Order(), g.AdjacencyList.jsonAdj(), t)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+//
+// Label tables, if present, are validated but discarded.
+func (g *Directed) UnmarshalJSON(data []byte) error {
+	_, err := g.UnmarshalJSONTables(data)
+	return err
+}
+
+// UnmarshalJSONTables unmarshals a directed graph and label tables.
+//
+// The JSON must have directed true, arcs must be in bounds, and a node label
+// table if present must have an element for each node.  On error, the
+// receiver is unchanged.
+func (g *Directed) UnmarshalJSONTables(data []byte) (LabelTables, error) {
+	var a AdjacencyList
+	t, err := unmarshalGraph(data, true, &a)
+	if err == nil {
+		err = a.validateJSON(t)
+	}
+	if err != nil {
+		return LabelTables{}, err
+	}
+	g.AdjacencyList = a
+	return t, nil
+}
+
+// MarshalJSON implements json.Marshaler.
+//
+// See LabelTables for the JSON schema.
+func (g Undirected) MarshalJSON() ([]byte, error) {
+	return g.MarshalJSONTables(LabelTables{})
+}
+
+// MarshalJSONTables marshals g with label tables.
+//
+// Table t.Nodes, if non-nil, must have the length of g.  See LabelTables
+// for the JSON schema.
+func (g Undirected) MarshalJSONTables(t LabelTables) ([]byte, error) {
+	return marshalGraph(false, g.Order(), g.AdjacencyList.jsonAdj(), t)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+//
+// Label tables, if present, are validated but discarded.
+func (g *Undirected) UnmarshalJSON(data []byte) error {
+	_, err := g.UnmarshalJSONTables(data)
+	return err
+}
+
+// UnmarshalJSONTables unmarshals an undirected graph and label tables.
+//
+// The JSON must have directed false, arcs must be in bounds, and all
+// non-loop arcs must be paired in reciprocal pairs.  A node label table if
+// present must have an element for each node.  On error, the receiver is
+// unchanged.
+func (g *Undirected) UnmarshalJSONTables(data []byte) (LabelTables, error) {
+	var a AdjacencyList
+	t, err := unmarshalGraph(data, false, &a)
+	if err == nil {
+		err = a.validateJSON(t)
+	}
+	if err == nil {
+		if u, fr, to := a.IsUndirected(); !u {
+			err = fmt.Errorf("arc %d->%d has no reciprocal", fr, to)
+		}
+	}
+	if err != nil {
+		return LabelTables{}, err
+	}
+	g.AdjacencyList = a
+	return t, nil
+}
+
+// MarshalJSON implements json.Marshaler.
+//
+// See LabelTables for the JSON schema.
+func (g LabeledDirected) MarshalJSON() ([]byte, error) {
+	return g.MarshalJSONTables(LabelTables{})
+}
+
+// MarshalJSONTables marshals g with label tables.
+//
+// Table t.Nodes, if non-nil, must have the length of g.  Table t.Edges, if
+// non-nil, must have an element for each label of g.  See LabelTables for
+// the JSON schema.
+func (g LabeledDirected) MarshalJSONTables(t LabelTables) ([]byte, error) {
+	a, err := g.LabeledAdjacencyList.jsonAdj(t.Edges)
+	if err != nil {
+		return nil, err
+	}
+	return marshalGraph(true, g.Order(), a, t)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+//
+// Label tables, if present, are validated but discarded.
+func (g *LabeledDirected) UnmarshalJSON(data []byte) error {
+	_, err := g.UnmarshalJSONTables(data)
+	return err
+}
+
+// UnmarshalJSONTables unmarshals a labeled directed graph and label tables.
+//
+// The JSON must have directed true and arcs must be in bounds.  A node label
+// table if present must have an element for each node.  An edge label table
+// if present must have an element for each arc label.  On error, the
+// receiver is unchanged.
+func (g *LabeledDirected) UnmarshalJSONTables(data []byte) (LabelTables, error) {
+	var p [][][2]int32
+	t, err := unmarshalGraph(data, true, &p)
+	if err != nil {
+		return LabelTables{}, err
+	}
+	a, err := fromJSONAdj(p, t)
+	if err != nil {
+		return LabelTables{}, err
+	}
+	g.LabeledAdjacencyList = a
+	return t, nil
+}
+
+// MarshalJSON implements json.Marshaler.
+//
+// See LabelTables for the JSON schema.
+func (g LabeledUndirected) MarshalJSON() ([]byte, error) {
+	return g.MarshalJSONTables(LabelTables{})
+}
+
+// MarshalJSONTables marshals g with label tables.
+//
+// Table t.Nodes, if non-nil, must have the length of g.  Table t.Edges, if
+// non-nil, must have an element for each label of g.  See LabelTables for
+// the JSON schema.
+func (g LabeledUndirected) MarshalJSONTables(t LabelTables) ([]byte, error) {
+	a, err := g.LabeledAdjacencyList.jsonAdj(t.Edges)
+	if err != nil {
+		return nil, err
+	}
+	return marshalGraph(false, g.Order(), a, t)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+//
+// Label tables, if present, are validated but discarded.
+func (g *LabeledUndirected) UnmarshalJSON(data []byte) error {
+	_, err := g.UnmarshalJSONTables(data)
+	return err
+}
+
+// UnmarshalJSONTables unmarshals a labeled undirected graph and label tables.
+//
+// The JSON must have directed false, arcs must be in bounds, and all
+// non-loop arcs must be paired in reciprocal pairs with matching labels.
+// A node label table if present must have an element for each node.  An edge
+// label table if present must have an element for each arc label.  On error,
+// the receiver is unchanged.
+func (g *LabeledUndirected) UnmarshalJSONTables(data []byte) (LabelTables, error) {
+	var p [][][2]int32
+	t, err := unmarshalGraph(data, false, &p)
+	if err != nil {
+		return LabelTables{}, err
+	}
+	a, err := fromJSONAdj(p, t)
+	if err != nil {
+		return LabelTables{}, err
+	}
+	if u, fr, to := a.IsUndirected(); !u {
+		return LabelTables{}, fmt.Errorf("arc %d->%d label %d has no reciprocal",
+			fr, to.To, to.Label)
+	}
+	g.LabeledAdjacencyList = a
+	return t, nil
+}
+
+// jsonFromList is the JSON schema for FromList.
+type jsonFromList struct {
+	Paths  [][2]int32 `json:"paths"`
+	MaxLen int        `json:"maxLen"`
+}
+
+// MarshalJSON implements json.Marshaler.
+//
+// The JSON schema for a FromList is an object with the members
+//
+//	paths   an array with a [from, len] pair for each node.
+//	maxLen  the MaxLen value.
+//
+// Leaves are not encoded.
+func (f FromList) MarshalJSON() ([]byte, error) {
+	p := make([][2]int32, len(f.Paths))
+	for n, e := range f.Paths {
+		p[n] = [2]int32{int32(e.From), int32(e.Len)}
+	}
+	return json.Marshal(jsonFromList{p, f.MaxLen})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+//
+// From values must be in bounds.  Leaves are recomputed with RecalcLeaves.
+// On error, the receiver is unchanged.
+func (f *FromList) UnmarshalJSON(data []byte) error {
+	var j jsonFromList
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	if j.Paths == nil {
+		return errors.New("JSON FromList missing paths")
+	}
+	d := FromList{Paths: make([]PathEnd, len(j.Paths)), MaxLen: j.MaxLen}
+	for n, p := range j.Paths {
+		d.Paths[n] = PathEnd{From: NI(p[0]), Len: int(p[1])}
+	}
+	if ok, n := d.BoundsOk(); !ok {
+		return fmt.Errorf("from value of node %d out of bounds", n)
+	}
+	d.RecalcLeaves()
+	*f = d
+	return nil
+}
+
+// jsonEdgeList is the JSON schema for WeightedEdgeList.
+type jsonEdgeList struct {
+	Order   int        `json:"order"`
+	Edges   [][3]int32 `json:"edges"`
+	Weights []float64  `json:"weights,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+//
+// The JSON schema for a WeightedEdgeList is an object with the members
+//
+//	order    the Order value.
+//	edges    an array with an [n1, n2, label] triple for each edge.
+//	weights  an array with the weight of each edge, as computed by
+//	         WeightFunc.  Omitted if WeightFunc is nil.
+func (l WeightedEdgeList) MarshalJSON() ([]byte, error) {
+	j := jsonEdgeList{Order: l.Order, Edges: make([][3]int32, len(l.Edges))}
+	for i, e := range l.Edges {
+		j.Edges[i] = [3]int32{int32(e.N1), int32(e.N2), int32(e.LI)}
+	}
+	if l.WeightFunc != nil {
+		j.Weights = make([]float64, len(l.Edges))
+		for i, e := range l.Edges {
+			j.Weights[i] = l.WeightFunc(e.LI)
+		}
+	}
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+//
+// Edge nodes must be in bounds of Order.  If weights are present, labels
+// must be non-negative and edges with the same label must have the same
+// weight.  WeightFunc is then set to a function returning weights by label.
+// If weights are not present, WeightFunc is set to nil.  On error, the
+// receiver is unchanged.
+func (l *WeightedEdgeList) UnmarshalJSON(data []byte) error {
+	var j jsonEdgeList
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	if j.Weights != nil && len(j.Weights) != len(j.Edges) {
+		return fmt.Errorf("%d weights for %d edges",
+			len(j.Weights), len(j.Edges))
+	}
+	d := WeightedEdgeList{Order: j.Order, Edges: make([]LabeledEdge, len(j.Edges))}
+	maxLabel := LI(-1)
+	for i, e := range j.Edges {
+		le := LabeledEdge{Edge{NI(e[0]), NI(e[1])}, LI(e[2])}
+		if le.N1 < 0 || int(le.N1) >= j.Order ||
+			le.N2 < 0 || int(le.N2) >= j.Order {
+			return fmt.Errorf("edge %d-%d out of bounds", le.N1, le.N2)
+		}
+		if j.Weights != nil && le.LI < 0 {
+			return fmt.Errorf("edge %d-%d has negative label", le.N1, le.N2)
+		}
+		if le.LI > maxLabel {
+			maxLabel = le.LI
+		}
+		d.Edges[i] = le
+	}
+	switch {
+	case j.Weights == nil:
+	case int(maxLabel) < len(d.Edges):
+		// labels are dense enough to index a slice no longer than the input.
+		w := make([]float64, maxLabel+1)
+		set := make([]bool, maxLabel+1)
+		for i, e := range d.Edges {
+			if set[e.LI] && w[e.LI] != j.Weights[i] {
+				return fmt.Errorf("label %d has conflicting weights", e.LI)
+			}
+			w[e.LI], set[e.LI] = j.Weights[i], true
+		}
+		d.WeightFunc = func(l LI) float64 { return w[l] }
+	default:
+		// sparse labels.  a slice indexed by label could be arbitrarily large.
+		w := make(map[LI]float64, len(d.Edges))
+		for i, e := range d.Edges {
+			if x, ok := w[e.LI]; ok && x != j.Weights[i] {
+				return fmt.Errorf("label %d has conflicting weights", e.LI)
+			}
+			w[e.LI] = j.Weights[i]
+		}
+		d.WeightFunc = func(l LI) float64 { return w[l] }
+	}
+	*l = d
+	return nil
+}
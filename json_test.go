@@ -0,0 +1,188 @@
+// Copyright 2026 Sonia Keys
+// License MIT: https://opensource.org/licenses/MIT
+
+package graph_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/soniakeys/graph"
+)
+
+func ExampleDirected_MarshalJSON() {
+	//   0
+	//  / \
+	// 1-->2
+	g := graph.Directed{graph.AdjacencyList{
+		0: {1, 2},
+		1: {2},
+		2: nil,
+	}}
+	b, err := json.Marshal(g)
+	fmt.Println(string(b), err)
+
+	var d graph.Directed
+	err = json.Unmarshal(b, &d)
+	fmt.Println(d.AdjacencyList, err)
+	// Output:
+	// {"directed":true,"adjacency":[[1,2],[2],[]]} <nil>
+	// [[1 2] [2] []] <nil>
+}
+
+func ExampleUndirected_UnmarshalJSON() {
+	var u graph.Undirected
+	err := json.Unmarshal([]byte(`{"directed":false,"adjacency":[[1],[0,1]]}`), &u)
+	fmt.Println(u.AdjacencyList, err)
+
+	// missing reciprocal
+	err = json.Unmarshal([]byte(`{"directed":false,"adjacency":[[1],[]]}`), &u)
+	fmt.Println(err)
+
+	// wrong type
+	var d graph.Directed
+	err = json.Unmarshal([]byte(`{"directed":false,"adjacency":[[1],[0]]}`), &d)
+	fmt.Println(err)
+	// Output:
+	// [[1] [0 1]] <nil>
+	// arc 0->1 has no reciprocal
+	// JSON graph has directed false, expected true
+}
+
+func ExampleLabeledDirected_MarshalJSONTables() {
+	g := graph.LabeledDirected{graph.LabeledAdjacencyList{
+		0: {{To: 1, Label: 0}, {To: 2, Label: 1}},
+		1: {{To: 2, Label: 0}},
+		2: nil,
+	}}
+	b, err := g.MarshalJSONTables(graph.LabelTables{
+		Nodes: []string{"a", "b", "c"},
+		Edges: []string{"x", "y"},
+	})
+	fmt.Println(string(b), err)
+
+	var d graph.LabeledDirected
+	t, err := d.UnmarshalJSONTables(b)
+	fmt.Println(d.LabeledAdjacencyList, err)
+	fmt.Println(t.Nodes, t.Edges)
+	// Output:
+	// {"directed":true,"adjacency":[[[1,0],[2,1]],[[2,0]],[]],"nodeLabels":["a","b","c"],"edgeLabels":["x","y"]} <nil>
+	// [[{1 0} {2 1}] [{2 0}] []] <nil>
+	// [a b c] [x y]
+}
+
+func ExampleLabeledUndirected_MarshalJSON() {
+	var g graph.LabeledUndirected
+	g.AddEdge(graph.Edge{0, 1}, 7)
+	b, err := json.Marshal(g)
+	fmt.Println(string(b), err)
+	// Output:
+	// {"directed":false,"adjacency":[[[1,7]],[[0,7]]]} <nil>
+}
+
+func ExampleFromList_MarshalJSON() {
+	//   0
+	//  / \
+	// 1   2
+	f := graph.FromList{Paths: []graph.PathEnd{
+		0: {From: -1, Len: 1},
+		1: {From: 0, Len: 2},
+		2: {From: 0, Len: 2},
+	}, MaxLen: 2}
+	b, err := json.Marshal(f)
+	fmt.Println(string(b), err)
+
+	var d graph.FromList
+	err = json.Unmarshal(b, &d)
+	fmt.Println(d.Paths, d.MaxLen, d.Leaves.Slice(), err)
+	// Output:
+	// {"paths":[[-1,1],[0,2],[0,2]],"maxLen":2} <nil>
+	// [{-1 1} {0 2} {0 2}] 2 [1 2] <nil>
+}
+
+func ExampleWeightedEdgeList_MarshalJSON() {
+	w := []float64{1.5, 2.5}
+	l := graph.WeightedEdgeList{
+		Order:      3,
+		WeightFunc: func(l graph.LI) float64 { return w[l] },
+		Edges: []graph.LabeledEdge{
+			{graph.Edge{0, 1}, 0},
+			{graph.Edge{1, 2}, 1},
+		},
+	}
+	b, err := json.Marshal(l)
+	fmt.Println(string(b), err)
+
+	var d graph.WeightedEdgeList
+	err = json.Unmarshal(b, &d)
+	fmt.Println(d.Order, d.Edges, d.WeightFunc(1), err)
+	// Output:
+	// {"order":3,"edges":[[0,1,0],[1,2,1]],"weights":[1.5,2.5]} <nil>
+	// 3 [{{0 1} 0} {{1 2} 1}] 2.5 <nil>
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	g, _, _ := graph.LabeledGeometric(100, .2, nil)
+	b, err := json.Marshal(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var d graph.LabeledUndirected
+	if err := json.Unmarshal(b, &d); err != nil {
+		t.Fatal(err)
+	}
+	for fr, to := range g.LabeledAdjacencyList {
+		if len(to) == 0 && len(d.LabeledAdjacencyList[fr]) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(d.LabeledAdjacencyList[fr], to) {
+			t.Fatal("mismatch at node", fr)
+		}
+	}
+}
+
+func TestJSONErrors(t *testing.T) {
+	for _, s := range []string{
+		`{"adjacency":[[]]}`,
+		`{"directed":false,"adjacency":[[]]}`,
+		`{"directed":true}`,
+		`{"directed":true,"adjacency":[[[1,0]]]}`,
+		`{"directed":true,"adjacency":[[[0,1]]],"edgeLabels":["x"]}`,
+		`{"directed":true,"adjacency":[[[0,0]]],"nodeLabels":["a","b"]}`,
+	} {
+		var d graph.LabeledDirected
+		if err := json.Unmarshal([]byte(s), &d); err == nil {
+			t.Errorf("no error for %s", s)
+		}
+		if d.LabeledAdjacencyList != nil {
+			t.Errorf("receiver modified for %s", s)
+		}
+	}
+	var u graph.LabeledUndirected
+	s := `{"directed":false,"adjacency":[[[1,0]],[[0,1]]]}`
+	if err := json.Unmarshal([]byte(s), &u); err == nil {
+		t.Error("mismatched reciprocal labels not detected")
+	}
+	var f graph.FromList
+	if err := json.Unmarshal([]byte(`{"paths":[[3,1]]}`), &f); err == nil {
+		t.Error("FromList out of bounds not detected")
+	}
+	var l graph.WeightedEdgeList
+	s = `{"order":2,"edges":[[0,1,0],[1,0,0]],"weights":[1,2]}`
+	if err := json.Unmarshal([]byte(s), &l); err == nil {
+		t.Error("conflicting weights not detected")
+	}
+	// a large label must not cause a large allocation or overflow.
+	s = `{"order":1,"edges":[[0,0,2147483647]],"weights":[1]}`
+	if err := json.Unmarshal([]byte(s), &l); err != nil {
+		t.Error("large label:", err)
+	} else if w := l.WeightFunc(2147483647); w != 1 {
+		t.Error("large label weight", w)
+	}
+	s = `{"order":1,"edges":[[0,0,2147483647],[0,0,2147483647]],"weights":[1,2]}`
+	if err := json.Unmarshal([]byte(s), &l); err == nil {
+		t.Error("conflicting weights of large label not detected")
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright 2026 Sonia Keys
+// License MIT: https://opensource.org/licenses/MIT
+
+package graph
+
+import "math/bits"
+
+// LCAIndex answers lowest common ancestor queries on a tree or forest.
+//
+// The index is built from a FromList.  Lowest common ancestor queries use an
+// Euler tour of the tree and a sparse table of range minimums and take
+// constant time.  Ancestor queries use a table of ancestors at power of two
+// distances, "binary lifting," and take O(log n) time.  Construction takes
+// O(n log n) time and memory.
+//
+// Construct with NewLCAIndex.
+type LCAIndex struct {
+	root  []NI      // root of the tree containing each node
+	depth []int     // number of arcs from root
+	wd    []float64 // weighted depth
+	first []int     // index of first occurrence of node in tour
+	// sparse[k][i] is the node of minimum depth in tour[i:i+1<<k].
+	// sparse[0] is the Euler tour itself.
+	sparse [][]NI
+	// up[k][n] is the ancestor of n at distance 1<<k, or -1.
+	up [][]NI
+}
+
+// NewLCAIndex builds an LCAIndex from FromList f.
+//
+// Only the From members of f.Paths are used.  Other members of the FromList
+// do not need to be valid.  The FromList cannot be cyclic.  It may
+// represent a forest, in which case queries on nodes of different trees
+// indicate that no common ancestor exists.
+//
+// Arguments labels and w are used by the Dist method to compute weighted
+// tree distances.  Labels[n] must be the label of the arc from
+// f.Paths[n].From to n, as returned for example by
+// LabeledDirected.FromListLabels.  Labels and w can be nil, in which case
+// every arc has weight 1.
+func NewLCAIndex(f FromList, labels []LI, w WeightFunc) *LCAIndex {
+	p := f.Paths
+	n := len(p)
+	x := &LCAIndex{
+		root:  make([]NI, n),
+		depth: make([]int, n),
+		wd:    make([]float64, n),
+		first: make([]int, n),
+	}
+	ch, _ := f.Transpose(nil)
+	tour := make([]NI, 0, 2*n)
+	// iterative depth first traversal producing the Euler tour.
+	type frame struct {
+		n NI
+		x int // index of next child
+	}
+	var stack []frame
+	for r := range p {
+		if p[r].From >= 0 {
+			continue
+		}
+		x.root[r] = NI(r)
+		x.first[r] = len(tour)
+		tour = append(tour, NI(r))
+		stack = append(stack[:0], frame{NI(r), 0})
+		for len(stack) > 0 {
+			top := &stack[len(stack)-1]
+			to := ch.AdjacencyList[top.n]
+			if top.x == len(to) {
+				stack = stack[:len(stack)-1]
+				if len(stack) > 0 {
+					tour = append(tour, stack[len(stack)-1].n)
+				}
+				continue
+			}
+			c := to[top.x]
+			top.x++
+			x.root[c] = NI(r)
+			x.depth[c] = x.depth[top.n] + 1
+			wt := 1.
+			if labels != nil {
+				wt = w(labels[c])
+			}
+			x.wd[c] = x.wd[top.n] + wt
+			x.first[c] = len(tour)
+			tour = append(tour, c)
+			stack = append(stack, frame{c, 0})
+		}
+	}
+	// sparse table of range minimums over the tour.
+	x.sparse = [][]NI{tour}
+	for k := 1; 1<<k <= len(tour); k++ {
+		prev := x.sparse[k-1]
+		h := 1 << (k - 1)
+		s := make([]NI, len(tour)-1<<k+1)
+		for i := range s {
+			s[i] = x.shallower(prev[i], prev[i+h])
+		}
+		x.sparse = append(x.sparse, s)
+	}
+	// ancestor table.
+	up0 := make([]NI, n)
+	for i, e := range p {
+		up0[i] = e.From
+	}
+	x.up = [][]NI{up0}
+	for k := 1; 1<<k < n; k++ {
+		prev := x.up[k-1]
+		u := make([]NI, n)
+		for i, a := range prev {
+			if a < 0 {
+				u[i] = -1
+			} else {
+				u[i] = prev[a]
+			}
+		}
+		x.up = append(x.up, u)
+	}
+	return x
+}
+
+func (x *LCAIndex) shallower(a, b NI) NI {
+	if x.depth[b] < x.depth[a] {
+		return b
+	}
+	return a
+}
+
+// LCA returns the lowest common ancestor of nodes a and b.
+//
+// A node is considered an ancestor of itself.  If a and b are in different
+// trees of a forest, LCA returns -1.
+func (x *LCAIndex) LCA(a, b NI) NI {
+	if x.root[a] != x.root[b] {
+		return -1
+	}
+	l, r := x.first[a], x.first[b]
+	if l > r {
+		l, r = r, l
+	}
+	k := bits.Len(uint(r-l+1)) - 1
+	s := x.sparse[k]
+	return x.shallower(s[l], s[r-1<<k+1])
+}
+
+// Depth returns the number of arcs in the path from the root to node n.
+func (x *LCAIndex) Depth(n NI) int {
+	return x.depth[n]
+}
+
+// Ancestor returns the ancestor of node n at distance k.
+//
+// Ancestor(n, 0) is n, Ancestor(n, 1) is the from-node of n, and so on.
+// If k is greater than the depth of n, Ancestor returns -1.
+func (x *LCAIndex) Ancestor(n NI, k int) NI {
+	if k > x.depth[n] {
+		return -1
+	}
+	for j := 0; k > 0; j++ {
+		if k&1 == 1 {
+			n = x.up[j][n]
+		}
+		k >>= 1
+	}
+	return n
+}
+
+// Len returns the number of arcs in the tree path between nodes a and b.
+//
+// If a and b are in different trees, Len returns -1.
+func (x *LCAIndex) Len(a, b NI) int {
+	c := x.LCA(a, b)
+	if c < 0 {
+		return -1
+	}
+	return x.depth[a] + x.depth[b] - 2*x.depth[c]
+}
+
+// Dist returns the weighted distance of the tree path between nodes a and b.
+//
+// Arc weights are determined by the labels and WeightFunc passed to
+// NewLCAIndex.  If a and b are in different trees, Dist returns ok = false.
+func (x *LCAIndex) Dist(a, b NI) (d float64, ok bool) {
+	c := x.LCA(a, b)
+	if c < 0 {
+		return 0, false
+	}
+	return x.wd[a] + x.wd[b] - 2*x.wd[c], true
+}
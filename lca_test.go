@@ -0,0 +1,93 @@
+// Copyright 2026 Sonia Keys
+// License MIT: https://opensource.org/licenses/MIT
+
+package graph_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/soniakeys/graph"
+)
+
+func ExampleLCAIndex() {
+	//       0
+	//    2 / \ 1
+	//     1   2
+	//  3 / \ 4 \ 5
+	//   3   4   5
+	g := graph.LabeledDirected{graph.LabeledAdjacencyList{
+		0: {{To: 1, Label: 2}, {To: 2, Label: 1}},
+		1: {{To: 3, Label: 3}, {To: 4, Label: 4}},
+		2: {{To: 5, Label: 5}},
+		5: {},
+	}}
+	f, labels, _ := g.FromListLabels()
+	x := graph.NewLCAIndex(*f, labels,
+		func(l graph.LI) float64 { return float64(l) })
+	fmt.Println(x.LCA(3, 4), x.LCA(3, 5), x.LCA(4, 1))
+	fmt.Println(x.Depth(4), x.Ancestor(4, 1), x.Ancestor(4, 2), x.Ancestor(4, 3))
+	fmt.Println(x.Len(3, 5))
+	fmt.Println(x.Dist(3, 5))
+	// Output:
+	// 1 0 1
+	// 2 1 0 -1
+	// 4
+	// 11 true
+}
+
+func TestLCAIndex(t *testing.T) {
+	r := rand.New(rand.NewSource(41))
+	// random forest
+	const n = 300
+	f := graph.NewFromList(n)
+	labels := make([]graph.LI, n)
+	w := make([]float64, n)
+	for i := range f.Paths {
+		f.Paths[i].From = -1
+		if i > 0 && r.Intn(20) > 0 {
+			f.Paths[i].From = graph.NI(r.Intn(i))
+		}
+		labels[i] = graph.LI(i)
+		w[i] = float64(r.Intn(10))
+	}
+	f.RecalcLeaves()
+	f.RecalcLen()
+	x := graph.NewLCAIndex(f, labels, func(l graph.LI) float64 { return w[l] })
+	for i := 0; i < 2000; i++ {
+		a := graph.NI(r.Intn(n))
+		b := graph.NI(r.Intn(n))
+		c := f.CommonStart(a, b)
+		if got := x.LCA(a, b); got != c {
+			t.Fatal("LCA", a, b, got, "want", c)
+		}
+		if x.Depth(a) != f.Paths[a].Len-1 {
+			t.Fatal("Depth", a)
+		}
+		k := r.Intn(f.Paths[a].Len + 1)
+		want := graph.NI(-1)
+		if k < f.Paths[a].Len {
+			want = f.PathTo(a, nil)[f.Paths[a].Len-1-k]
+		}
+		if got := x.Ancestor(a, k); got != want {
+			t.Fatal("Ancestor", a, k, got, "want", want)
+		}
+		d, ok := x.Dist(a, b)
+		if ok != (c >= 0) {
+			t.Fatal("Dist ok", a, b)
+		}
+		if !ok {
+			continue
+		}
+		s := 0.
+		for _, e := range []graph.NI{a, b} {
+			for ; e != c; e = f.Paths[e].From {
+				s += w[labels[e]]
+			}
+		}
+		if d != s {
+			t.Fatal("Dist", a, b, d, "want", s)
+		}
+	}
+}
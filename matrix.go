@@ -0,0 +1,293 @@
+// Copyright 2026 Sonia Keys
+// License MIT: https://opensource.org/licenses/MIT
+
+package graph
+
+import (
+	"github.com/soniakeys/bits"
+)
+
+// AdjacencyMatrix represents a graph as a dense matrix of bits.
+//
+// Each element is a row of the matrix, a bits.Bits with Num equal to the
+// length of the matrix.  Bit `to` of row `fr` is 1 if the graph has an arc
+// from fr to to.
+//
+// The representation takes space proportional to the square of the number
+// of nodes but allows constant time arc tests and word-parallel set
+// operations on neighborhoods.  It cannot represent parallel arcs.
+//
+// An AdjacencyMatrix can represent either a directed or undirected graph.
+// For an undirected graph the matrix is symmetric.  Methods document which
+// they assume.
+type AdjacencyMatrix []bits.Bits
+
+// NewAdjacencyMatrix creates an AdjacencyMatrix with n nodes and no arcs.
+func NewAdjacencyMatrix(n int) AdjacencyMatrix {
+	m := make(AdjacencyMatrix, n)
+	for i := range m {
+		m[i] = bits.New(n)
+	}
+	return m
+}
+
+// AdjacencyMatrix constructs an adjacency matrix from an adjacency list.
+//
+// The method is promoted to Directed and Undirected.  Parallel arcs are
+// collapsed to single matrix entries.
+func (g AdjacencyList) AdjacencyMatrix() AdjacencyMatrix {
+	m := NewAdjacencyMatrix(len(g))
+	for fr, to := range g {
+		r := m[fr]
+		for _, to := range to {
+			r.SetBit(int(to), 1)
+		}
+	}
+	return m
+}
+
+// AdjacencyList converts m to an adjacency list.
+//
+// Arc lists are in ascending order.
+func (m AdjacencyMatrix) AdjacencyList() AdjacencyList {
+	g := make(AdjacencyList, len(m))
+	for fr, r := range m {
+		r.IterateOnes(func(to int) bool {
+			g[fr] = append(g[fr], NI(to))
+			return true
+		})
+	}
+	return g
+}
+
+// Order returns the number of nodes of m.
+func (m AdjacencyMatrix) Order() int {
+	return len(m)
+}
+
+// HasArc returns true if m has an arc from node fr to node to.
+func (m AdjacencyMatrix) HasArc(fr, to NI) bool {
+	return m[fr].Bit(int(to)) == 1
+}
+
+// AddArc adds an arc from node fr to node to.
+//
+// The matrix does not expand.  Nodes fr and to must be less than the order
+// of m.
+func (m AdjacencyMatrix) AddArc(fr, to NI) {
+	m[fr].SetBit(int(to), 1)
+}
+
+// AddEdge adds arcs in both directions between nodes n1 and n2.
+//
+// The matrix does not expand.  Nodes n1 and n2 must be less than the order
+// of m.
+func (m AdjacencyMatrix) AddEdge(n1, n2 NI) {
+	m[n1].SetBit(int(n2), 1)
+	m[n2].SetBit(int(n1), 1)
+}
+
+// Degree returns the number of arcs from node n.
+func (m AdjacencyMatrix) Degree(n NI) int {
+	return m[n].OnesCount()
+}
+
+// CommonNeighbors returns the nodes adjacent from both n1 and n2.
+func (m AdjacencyMatrix) CommonNeighbors(n1, n2 NI) bits.Bits {
+	var c bits.Bits
+	c.And(m[n1], m[n2])
+	return c
+}
+
+// NeighborsIn returns the nodes of s adjacent from node n.
+//
+// Argument s must have Num equal to the order of m.
+func (m AdjacencyMatrix) NeighborsIn(n NI, s bits.Bits) bits.Bits {
+	var c bits.Bits
+	c.And(m[n], s)
+	return c
+}
+
+// Bipartite determines if a connected component of an undirected graph
+// is bipartite.
+//
+// The method is equivalent to Undirected.Bipartite, producing identical
+// results for a matrix constructed from an Undirected.
+func (m AdjacencyMatrix) Bipartite(n NI) (b bool, c1, c2 bits.Bits, oc []NI) {
+	c1 = bits.New(len(m))
+	c2 = bits.New(len(m))
+	b = true
+	var open bool
+	var df func(n NI, c1, c2 *bits.Bits)
+	df = func(n NI, c1, c2 *bits.Bits) {
+		c1.SetBit(int(n), 1)
+		m[n].IterateOnes(func(nb int) bool {
+			if c1.Bit(nb) == 1 {
+				b = false
+				oc = []NI{NI(nb), n}
+				open = true
+				return false
+			}
+			if c2.Bit(nb) == 1 {
+				return true
+			}
+			df(NI(nb), c2, c1)
+			if b {
+				return true
+			}
+			switch {
+			case !open:
+			case n == oc[0]:
+				open = false
+			default:
+				oc = append(oc, n)
+			}
+			return false
+		})
+	}
+	df(n, &c1, &c2)
+	if b {
+		return b, c1, c2, nil
+	}
+	return b, bits.Bits{}, bits.Bits{}, oc
+}
+
+// BronKerbosch1 finds maximal cliques in an undirected graph.
+//
+// The method is equivalent to Undirected.BronKerbosch1, emitting identical
+// cliques in identical order for a matrix constructed from an Undirected.
+// The matrix must not have loops.  As with Undirected, the bits passed to
+// emit are valid only for the duration of the call.
+func (m AdjacencyMatrix) BronKerbosch1(emit func(bits.Bits) bool) {
+	var f func(R, P, X bits.Bits) bool
+	f = func(R, P, X bits.Bits) bool {
+		switch {
+		case !P.AllZeros():
+			r2 := bits.New(len(m))
+			var p2, x2 bits.Bits
+			pf := func(n int) bool {
+				r2.Set(R)
+				r2.SetBit(n, 1)
+				p2.And(P, m[n])
+				x2.And(X, m[n])
+				if !f(r2, p2, x2) {
+					return false
+				}
+				P.SetBit(n, 0)
+				X.SetBit(n, 1)
+				return true
+			}
+			if !P.IterateOnes(pf) {
+				return false
+			}
+		case X.AllZeros():
+			return emit(R)
+		}
+		return true
+	}
+	R := bits.New(len(m))
+	P := bits.New(len(m))
+	X := bits.New(len(m))
+	P.SetAll()
+	f(R, P, X)
+}
+
+// BKPivotMaxDegree is a strategy for BronKerbosch methods.
+//
+// The method is equivalent to Undirected.BKPivotMaxDegree.
+func (m AdjacencyMatrix) BKPivotMaxDegree(P, X bits.Bits) (p NI) {
+	maxDeg := -1
+	scan := func(n int) bool {
+		if d := m[n].OnesCount(); d > maxDeg {
+			p = NI(n)
+			maxDeg = d
+		}
+		return true
+	}
+	P.IterateOnes(scan)
+	X.IterateOnes(scan)
+	return
+}
+
+// BKPivotMinP is a strategy for BronKerbosch methods.
+//
+// The method is equivalent to Undirected.BKPivotMinP.
+func (m AdjacencyMatrix) BKPivotMinP(P, X bits.Bits) NI {
+	return NI(P.OneFrom(0))
+}
+
+// bk2 is the recursive function common to BronKerbosch2 and 3.
+func (m AdjacencyMatrix) bk2(pivot func(P, X bits.Bits) NI, emit func(bits.Bits) bool) func(R, P, X bits.Bits) bool {
+	var f func(R, P, X bits.Bits) bool
+	f = func(R, P, X bits.Bits) bool {
+		switch {
+		case !P.AllZeros():
+			r2 := bits.New(len(m))
+			var p2, x2, pnu bits.Bits
+			// compute P \ N(u)
+			pnu.AndNot(P, m[pivot(P, X)])
+			pf := func(n int) bool {
+				r2.Set(R)
+				r2.SetBit(n, 1)
+				p2.And(P, m[n])
+				x2.And(X, m[n])
+				if !f(r2, p2, x2) {
+					return false
+				}
+				P.SetBit(n, 0)
+				X.SetBit(n, 1)
+				return true
+			}
+			if !pnu.IterateOnes(pf) {
+				return false
+			}
+		case X.AllZeros():
+			return emit(R)
+		}
+		return true
+	}
+	return f
+}
+
+// BronKerbosch2 finds maximal cliques in an undirected graph.
+//
+// The method is equivalent to Undirected.BronKerbosch2, emitting identical
+// cliques in identical order for a matrix constructed from an Undirected
+// and an equivalent pivot function.  The matrix must not have loops.
+// As with Undirected, the bits passed to emit are valid only for the
+// duration of the call.
+func (m AdjacencyMatrix) BronKerbosch2(pivot func(P, X bits.Bits) NI, emit func(bits.Bits) bool) {
+	R := bits.New(len(m))
+	P := bits.New(len(m))
+	X := bits.New(len(m))
+	P.SetAll()
+	m.bk2(pivot, emit)(R, P, X)
+}
+
+// BronKerbosch3 finds maximal cliques in an undirected graph.
+//
+// The method is equivalent to Undirected.BronKerbosch3, emitting identical
+// cliques in identical order for a matrix constructed from an Undirected
+// and an equivalent pivot function.  The matrix must not have loops.
+// As with Undirected, the bits passed to emit are valid only for the
+// duration of the call.
+func (m AdjacencyMatrix) BronKerbosch3(pivot func(P, X bits.Bits) NI, emit func(bits.Bits) bool) {
+	f := m.bk2(pivot, emit)
+	R := bits.New(len(m))
+	P := bits.New(len(m))
+	X := bits.New(len(m))
+	P.SetAll()
+	_, ord, _ := Undirected{m.AdjacencyList()}.Degeneracy()
+	var p2, x2 bits.Bits
+	for _, n := range ord {
+		R.SetBit(int(n), 1)
+		p2.And(P, m[n])
+		x2.And(X, m[n])
+		if !f(R, p2, x2) {
+			return
+		}
+		R.SetBit(int(n), 0)
+		P.SetBit(int(n), 0)
+		X.SetBit(int(n), 1)
+	}
+}
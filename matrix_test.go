@@ -0,0 +1,133 @@
+// Copyright 2026 Sonia Keys
+// License MIT: https://opensource.org/licenses/MIT
+
+package graph_test
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/soniakeys/bits"
+	"github.com/soniakeys/graph"
+)
+
+func ExampleAdjacencyList_AdjacencyMatrix() {
+	// 0---1
+	// |  /|
+	// | / |
+	// |/  |
+	// 2---3
+	var g graph.Undirected
+	g.AddEdge(0, 1)
+	g.AddEdge(0, 2)
+	g.AddEdge(1, 2)
+	g.AddEdge(1, 3)
+	g.AddEdge(2, 3)
+	m := g.AdjacencyMatrix()
+	for _, r := range m {
+		fmt.Println(r)
+	}
+	fmt.Println(m.HasArc(0, 3), m.HasArc(3, 1))
+	fmt.Println(m.CommonNeighbors(0, 3).Slice())
+	// Output:
+	// 0110
+	// 1101
+	// 1011
+	// 0110
+	// false true
+	// [1 2]
+}
+
+func ExampleAdjacencyMatrix_BronKerbosch1() {
+	// 0---1
+	// |  /|
+	// | / |
+	// |/  |
+	// 2---3
+	m := graph.NewAdjacencyMatrix(4)
+	m.AddEdge(0, 1)
+	m.AddEdge(0, 2)
+	m.AddEdge(1, 2)
+	m.AddEdge(1, 3)
+	m.AddEdge(2, 3)
+	m.BronKerbosch1(func(c bits.Bits) bool {
+		fmt.Println(c.Slice())
+		return true
+	})
+	// Output:
+	// [0 1 2]
+	// [1 2 3]
+}
+
+func ExampleAdjacencyMatrix_Bipartite() {
+	// 0---1
+	// |   |
+	// 3---2
+	m := graph.NewAdjacencyMatrix(4)
+	m.AddEdge(0, 1)
+	m.AddEdge(1, 2)
+	m.AddEdge(2, 3)
+	m.AddEdge(3, 0)
+	b, c1, c2, _ := m.Bipartite(0)
+	fmt.Println(b, c1.Slice(), c2.Slice())
+	m.AddEdge(0, 2)
+	b, _, _, oc := m.Bipartite(0)
+	fmt.Println(b, oc)
+	// Output:
+	// true [0 2] [1 3]
+	// false [0 2 1]
+}
+
+func TestAdjacencyMatrixBK(t *testing.T) {
+	g := graph.GnmUndirected(60, 600, rand.New(rand.NewSource(32)))
+	g.SortArcLists()
+	m := g.AdjacencyMatrix()
+	if !reflect.DeepEqual(m.AdjacencyList(), g.AdjacencyList) {
+		t.Fatal("conversion mismatch")
+	}
+	collect := func(bk func(func(bits.Bits) bool)) (cl [][]int) {
+		bk(func(c bits.Bits) bool {
+			cl = append(cl, c.Slice())
+			return true
+		})
+		return
+	}
+	if !reflect.DeepEqual(collect(m.BronKerbosch1), collect(g.BronKerbosch1)) {
+		t.Fatal("BronKerbosch1 mismatch")
+	}
+	c1 := collect(func(e func(bits.Bits) bool) {
+		m.BronKerbosch2(m.BKPivotMaxDegree, e)
+	})
+	c2 := collect(func(e func(bits.Bits) bool) {
+		g.BronKerbosch2(g.BKPivotMaxDegree, e)
+	})
+	if !reflect.DeepEqual(c1, c2) {
+		t.Fatal("BronKerbosch2 mismatch")
+	}
+	c1 = collect(func(e func(bits.Bits) bool) {
+		m.BronKerbosch3(m.BKPivotMinP, e)
+	})
+	c2 = collect(func(e func(bits.Bits) bool) {
+		g.BronKerbosch3(g.BKPivotMinP, e)
+	})
+	if !reflect.DeepEqual(c1, c2) {
+		t.Fatal("BronKerbosch3 mismatch")
+	}
+}
+
+func TestAdjacencyMatrixBipartite(t *testing.T) {
+	g := graph.GnmUndirected(40, 30, rand.New(rand.NewSource(32)))
+	g.SortArcLists()
+	m := g.AdjacencyMatrix()
+	for n := range g.AdjacencyList {
+		b1, x1, y1, oc1 := g.Bipartite(graph.NI(n))
+		b2, x2, y2, oc2 := m.Bipartite(graph.NI(n))
+		if b1 != b2 || !reflect.DeepEqual(oc1, oc2) ||
+			!reflect.DeepEqual(x1.Slice(), x2.Slice()) ||
+			!reflect.DeepEqual(y1.Slice(), y2.Slice()) {
+			t.Fatal("Bipartite mismatch at node", n)
+		}
+	}
+}
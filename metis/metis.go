@@ -0,0 +1,315 @@
+// Copyright 2026 Sonia Keys
+// License MIT: https://opensource.org/licenses/MIT
+
+// Package metis reads and writes undirected graphs in the METIS graph
+// file format.
+//
+// The format is described in the METIS manual, section "Graph file."
+// A header line gives the number of nodes, number of edges, and
+// optionally a format code and number of node weights.  Then each line
+// following the header lists the neighbors of a single node, optionally
+// preceded by node weights and with each neighbor optionally followed
+// by an edge weight.  Lines beginning with % are comments.
+//
+// Like package dot, metis is a separate package from graph.  It imports
+// graph; graph knows nothing of metis.
+//
+// METIS files number nodes from 1.  Node n of a file becomes graph.NI n-1.
+//
+// Graphs are read as graph.LabeledUndirected where reciprocal arcs share
+// a label.  Labels number the edges from 0 in the order they are first
+// encountered in the file.  Edge weights, if present in the file, are
+// returned in a slice indexed by label.
+package metis
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/soniakeys/graph"
+)
+
+// allocMax limits preallocation from header counts.
+//
+// Counts are not trusted until the lines they describe have been read.
+// Slices start no larger than this and grow as lines are read.
+const allocMax = 1 << 16
+
+// minAlloc returns c bounded by allocMax.
+func minAlloc(c int) int {
+	if c > allocMax {
+		return allocMax
+	}
+	return c
+}
+
+// Read reads a graph in METIS format.
+//
+// Returned are the graph, edge weights indexed by label, and node weights
+// indexed by node.  If the file has no edge weights, ew will be nil.
+// If the file has no node weights, nw will be nil.  Otherwise each element
+// of nw holds the node weights for a single node, as many as specified by
+// the "ncon" field of the header.  Node sizes, if present, are validated
+// but not returned.
+func Read(r io.Reader) (g graph.LabeledUndirected, ew []float64, nw [][]float64, err error) {
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, 1<<24)
+	line := 0
+	errorf := func(format string, a ...interface{}) error {
+		return fmt.Errorf("metis: line %d: %s", line, fmt.Sprintf(format, a...))
+	}
+	// next returns fields of the next non-comment line.
+	// Blank lines are significant, they represent nodes with no neighbors.
+	next := func() ([]string, bool) {
+		for s.Scan() {
+			line++
+			t := s.Text()
+			if !strings.HasPrefix(t, "%") {
+				return strings.Fields(t), true
+			}
+		}
+		return nil, false
+	}
+	// header
+	var h []string
+	for {
+		f, ok := next()
+		if !ok {
+			if err = s.Err(); err == nil {
+				err = errors.New("metis: missing header")
+			}
+			return
+		}
+		if len(f) > 0 {
+			h = f
+			break
+		}
+	}
+	if len(h) > 4 {
+		err = errorf("too many header fields")
+		return
+	}
+	var hv [4]int
+	hv[3] = 1 // ncon default
+	for i, f := range h {
+		if i == 2 {
+			continue // fmt is a string of binary digits
+		}
+		// counts must fit graph.NI and graph.LI
+		if hv[i], err = strconv.Atoi(f); err != nil ||
+			hv[i] < 0 || hv[i] > math.MaxInt32 {
+			err = errorf("invalid header field %q", f)
+			return
+		}
+	}
+	n, m, ncon := hv[0], hv[1], hv[3]
+	var hasSize, hasNW, hasEW bool
+	if len(h) > 2 {
+		f := h[2]
+		if len(f) > 3 || strings.Trim(f, "01") != "" {
+			err = errorf("invalid format %q", f)
+			return
+		}
+		f = strings.Repeat("0", 3-len(f)) + f
+		hasSize, hasNW, hasEW = f[0] == '1', f[1] == '1', f[2] == '1'
+	}
+	if !hasNW && len(h) > 3 {
+		err = errorf("ncon specified without node weights")
+		return
+	}
+	a := make(graph.LabeledAdjacencyList, 0, minAlloc(n))
+	if hasEW {
+		ew = make([]float64, 0, minAlloc(m))
+	}
+	if hasNW {
+		nw = make([][]float64, 0, minAlloc(n))
+	}
+	nLabels := 0 // number of labels assigned
+	nPairs := 0  // number of arcs matched to reciprocals
+	for u := 0; u < n; u++ {
+		f, ok := next()
+		if !ok {
+			if err = s.Err(); err == nil {
+				err = fmt.Errorf("metis: found %d nodes, header specified %d",
+					u, n)
+			}
+			return
+		}
+		a = append(a, nil)
+		if hasSize {
+			if len(f) == 0 {
+				err = errorf("missing node size")
+				return
+			}
+			if _, err = strconv.ParseFloat(f[0], 64); err != nil {
+				err = errorf("invalid node size %q", f[0])
+				return
+			}
+			f = f[1:]
+		}
+		if hasNW {
+			if len(f) < ncon {
+				err = errorf("missing node weights")
+				return
+			}
+			w := make([]float64, ncon)
+			for i := range w {
+				if w[i], err = strconv.ParseFloat(f[i], 64); err != nil {
+					err = errorf("invalid node weight %q", f[i])
+					return
+				}
+			}
+			nw = append(nw, w)
+			f = f[ncon:]
+		}
+		step := 1
+		if hasEW {
+			step = 2
+			if len(f)%2 != 0 {
+				err = errorf("missing edge weight")
+				return
+			}
+		}
+		for i := 0; i < len(f); i += step {
+			var v int
+			v, err = strconv.Atoi(f[i])
+			if err != nil || v < 1 || v > n {
+				err = errorf("invalid node %q", f[i])
+				return
+			}
+			v--
+			if v == u {
+				err = errorf("loop on node %d", v+1)
+				return
+			}
+			var w float64
+			if hasEW {
+				if w, err = strconv.ParseFloat(f[i+1], 64); err != nil {
+					err = errorf("invalid edge weight %q", f[i+1])
+					return
+				}
+			}
+			if v > u { // new edge
+				a[u] = append(a[u], graph.Half{To: graph.NI(v),
+					Label: graph.LI(nLabels)})
+				if hasEW {
+					ew = append(ew, w)
+				}
+				nLabels++
+				continue
+			}
+			// v < u, find reciprocal
+			l := graph.LI(-1)
+			for _, h := range a[v] {
+				if h.To == graph.NI(u) {
+					l = h.Label
+					break
+				}
+			}
+			switch {
+			case l < 0:
+				err = errorf("arc %d->%d has no reciprocal", u+1, v+1)
+				return
+			case hasEW && ew[l] != w:
+				err = errorf("edge %d-%d has asymmetric weights", v+1, u+1)
+				return
+			}
+			a[u] = append(a[u], graph.Half{To: graph.NI(v), Label: l})
+			nPairs++
+		}
+	}
+	if err = s.Err(); err != nil {
+		return
+	}
+	if nPairs != nLabels {
+		err = errors.New("metis: graph not undirected")
+		return
+	}
+	if nLabels != m {
+		err = fmt.Errorf("metis: found %d edges, header specified %d",
+			nLabels, m)
+		return
+	}
+	for {
+		f, ok := next()
+		if !ok {
+			break
+		}
+		if len(f) > 0 {
+			err = errorf("extra node line")
+			return
+		}
+	}
+	g.LabeledAdjacencyList = a
+	return g, ew, nw, s.Err()
+}
+
+// Write writes an undirected graph in METIS format.
+//
+// Argument g must be a simple undirected graph.  Argument ew, if non-nil,
+// is used to write edge weights.  Argument nw, if non-nil, must have the
+// length of g and each element must have the same length, which is written
+// as the "ncon" field of the header.
+func Write(wr io.Writer, g graph.LabeledUndirected, ew graph.WeightFunc, nw [][]float64) error {
+	ncon := 0
+	if nw != nil {
+		if len(nw) != g.Order() {
+			return errors.New("metis: node weights do not match graph order")
+		}
+		if len(nw) > 0 {
+			ncon = len(nw[0])
+		}
+	}
+	b := bufio.NewWriter(wr)
+	if _, err := fmt.Fprint(b, g.Order(), " ", g.Size()); err != nil {
+		return err
+	}
+	switch {
+	case nw != nil && ew != nil:
+		fmt.Fprint(b, " 011")
+	case nw != nil:
+		fmt.Fprint(b, " 010")
+	case ew != nil:
+		fmt.Fprint(b, " 001")
+	}
+	if ncon > 1 {
+		fmt.Fprint(b, " ", ncon)
+	}
+	for u, to := range g.LabeledAdjacencyList {
+		if _, err := b.WriteString("\n"); err != nil {
+			return err
+		}
+		sep := ""
+		if nw != nil {
+			if len(nw[u]) != ncon {
+				return fmt.Errorf("metis: node %d has %d weights, want %d",
+					u, len(nw[u]), ncon)
+			}
+			for _, w := range nw[u] {
+				b.WriteString(sep + fmtWeight(w))
+				sep = " "
+			}
+		}
+		for _, to := range to {
+			b.WriteString(sep + strconv.Itoa(int(to.To)+1))
+			sep = " "
+			if ew != nil {
+				b.WriteString(" " + fmtWeight(ew(to.Label)))
+			}
+		}
+	}
+	if _, err := b.WriteString("\n"); err != nil {
+		return err
+	}
+	return b.Flush()
+}
+
+// fmtWeight formats a weight without an exponent.
+func fmtWeight(w float64) string {
+	return strconv.FormatFloat(w, 'f', -1, 64)
+}
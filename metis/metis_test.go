@@ -0,0 +1,116 @@
+// Copyright 2026 Sonia Keys
+// License MIT: https://opensource.org/licenses/MIT
+
+package metis_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/soniakeys/graph"
+	"github.com/soniakeys/graph/metis"
+)
+
+func ExampleRead() {
+	// 0---1
+	//  \ /
+	//   2   3
+	f := `% a triangle and an isolated node
+4 3 001
+2 5 3 7
+1 5 3 2
+1 7 2 2
+
+`
+	g, ew, nw, err := metis.Read(strings.NewReader(f))
+	fmt.Println(err)
+	for fr, to := range g.LabeledAdjacencyList {
+		fmt.Println(fr, to)
+	}
+	fmt.Println(ew, nw == nil)
+	// Output:
+	// <nil>
+	// 0 [{1 0} {2 1}]
+	// 1 [{0 0} {2 2}]
+	// 2 [{0 1} {1 2}]
+	// 3 []
+	// [5 7 2] true
+}
+
+func ExampleWrite() {
+	// 0---1
+	//  \ /
+	//   2
+	var g graph.LabeledUndirected
+	g.AddEdge(graph.Edge{0, 1}, 0)
+	g.AddEdge(graph.Edge{0, 2}, 1)
+	g.AddEdge(graph.Edge{1, 2}, 2)
+	ew := []float64{5, 7, 2}
+	nw := [][]float64{{1}, {2}, {3}}
+	metis.Write(os.Stdout, g, func(l graph.LI) float64 { return ew[l] }, nw)
+	// Output:
+	// 3 3 011
+	// 1 2 5 3 7
+	// 2 1 5 3 2
+	// 3 1 7 2 2
+}
+
+func TestRoundTrip(t *testing.T) {
+	g := graph.GnmUndirected(200, 600, nil)
+	var lg graph.LabeledUndirected
+	x := 0
+	g.Edges(func(e graph.Edge) {
+		lg.AddEdge(e, graph.LI(x))
+		x++
+	})
+	for len(lg.LabeledAdjacencyList) < g.Order() {
+		lg.LabeledAdjacencyList = append(lg.LabeledAdjacencyList, nil)
+	}
+	w := func(l graph.LI) float64 { return float64(l) + .5 }
+	var b bytes.Buffer
+	if err := metis.Write(&b, lg, w, nil); err != nil {
+		t.Fatal(err)
+	}
+	g2, ew, nw, err := metis.Read(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nw != nil || g2.Order() != lg.Order() || g2.Size() != lg.Size() {
+		t.Fatal("round trip mismatch")
+	}
+	for fr, to := range lg.LabeledAdjacencyList {
+		to2 := g2.LabeledAdjacencyList[fr]
+		if len(to2) != len(to) {
+			t.Fatal("arc count mismatch at node", fr)
+		}
+		for i, h := range to {
+			if to2[i].To != h.To || ew[to2[i].Label] != w(h.Label) {
+				t.Fatal("arc mismatch at node", fr)
+			}
+		}
+	}
+}
+
+func TestErrors(t *testing.T) {
+	for _, f := range []string{
+		"",
+		"2 1 001\n2\n1 3\n",                    // missing edge weight
+		"2 1\n2\n\n",                           // no reciprocal
+		"2 1\n1\n\n",                           // loop
+		"2 1\n3\n\n",                           // out of range
+		"2 2\n2\n1\n",                          // wrong edge count
+		"2 1\n2\n1\n2\n",                       // extra line
+		"2 1 001\n2 3\n1 4\n",                  // asymmetric weight
+		"2 1 000 2\n2\n1\n",                    // ncon without node weights
+		"2 1 010 2\n1 2\n1 1\n",                // missing node weights
+		"4294967297 1\n2\n1\n",                 // count out of range
+		"2147483647 2147483647 011 1\n1 2 1\n", // hostile counts
+	} {
+		if _, _, _, err := metis.Read(strings.NewReader(f)); err == nil {
+			t.Errorf("no error for %q", f)
+		}
+	}
+}
@@ -5,82 +5,14 @@ package graph
 
 import (
 	"container/heap"
+	"fmt"
+	"runtime"
 	"sort"
+	"sync"
 
 	"github.com/soniakeys/bits"
 )
 
-type dsElement struct {
-	from NI
-	rank int
-}
-
-type disjointSet struct {
-	set []dsElement
-}
-
-func newDisjointSet(n int) disjointSet {
-	set := make([]dsElement, n)
-	for i := range set {
-		set[i].from = -1
-	}
-	return disjointSet{set}
-}
-
-// return true if disjoint trees were combined.
-// false if x and y were already in the same tree.
-func (ds disjointSet) union(x, y NI) bool {
-	xr := ds.find(x)
-	yr := ds.find(y)
-	if xr == yr {
-		return false
-	}
-	switch xe, ye := &ds.set[xr], &ds.set[yr]; {
-	case xe.rank < ye.rank:
-		xe.from = yr
-	case xe.rank == ye.rank:
-		xe.rank++
-		fallthrough
-	default:
-		ye.from = xr
-	}
-	return true
-}
-
-func (ds disjointSet) find(n NI) NI {
-	// fast paths for n == root or from root.
-	// no updates need in these cases.
-	s := ds.set
-	fr := s[n].from
-	if fr < 0 { // n is root
-		return n
-	}
-	n, fr = fr, s[fr].from
-	if fr < 0 { // n is from root
-		return n
-	}
-	// otherwise updates needed.
-	// two iterative passes (rather than recursion or stack)
-	// pass 1: find root
-	r := fr
-	for {
-		f := s[r].from
-		if f < 0 {
-			break
-		}
-		r = f
-	}
-	// pass 2: update froms
-	for {
-		s[n].from = r
-		if fr == r {
-			return r
-		}
-		n = fr
-		fr = s[n].from
-	}
-}
-
 // Kruskal implements Kruskal's algorithm for constructing a minimum spanning
 // forest on an undirected graph.
 //
@@ -127,15 +59,253 @@ func (l WeightedEdgeList) Kruskal() (g LabeledUndirected, dist float64) {
 // The forest is returned as an undirected graph.
 //
 // Also returned is a total distance for the returned forest.
+//
+// This method is a wrapper for the generic function KruskalSorted.
 func (l WeightedEdgeList) KruskalSorted() (g LabeledUndirected, dist float64) {
-	ds := newDisjointSet(l.Order)
-	g.LabeledAdjacencyList = make(LabeledAdjacencyList, l.Order)
-	for _, e := range l.Edges {
-		if ds.union(e.N1, e.N2) {
+	return KruskalSorted(l.Order, l.Edges, l.WeightFunc)
+}
+
+// Kruskal implements Kruskal's algorithm for constructing a minimum spanning
+// forest on an undirected graph.
+//
+// It is the generic version of WeightedEdgeList.Kruskal, accepting any
+// Weight type.  Argument order is the number of nodes of the graph, edges
+// is the edge list, and w returns a weight for an edge label.  The edge
+// list is sorted in place.  See the method for documentation.
+func Kruskal[W Weight](order int, edges []LabeledEdge, w func(LI) W) (g LabeledUndirected, dist W) {
+	sort.Slice(edges, func(i, j int) bool {
+		return w(edges[i].LI) < w(edges[j].LI)
+	})
+	return KruskalSorted(order, edges, w)
+}
+
+// KruskalSorted implements Kruskal's algorithm for constructing a minimum
+// spanning tree on an undirected graph.
+//
+// It is the generic version of WeightedEdgeList.KruskalSorted, accepting any
+// Weight type.  Arguments are as for the generic function Kruskal except
+// that edges must be already sorted by weight.
+func KruskalSorted[W Weight](order int, edges []LabeledEdge, w func(LI) W) (g LabeledUndirected, dist W) {
+	ds := NewDisjointSet(order)
+	g.LabeledAdjacencyList = make(LabeledAdjacencyList, order)
+	for _, e := range edges {
+		if ds.Union(e.N1, e.N2) {
 			g.AddEdge(Edge{e.N1, e.N2}, e.LI)
-			dist += l.WeightFunc(e.LI)
+			dist += w(e.LI)
+		}
+	}
+	return
+}
+
+// Boruvka implements Borůvka's algorithm for constructing a minimum spanning
+// forest on an undirected graph.
+//
+// This method is a convenience wrapper for WeightedEdgeList.Boruvka.
+// Return values are as for Kruskal.
+func (g LabeledUndirected) Boruvka(w WeightFunc) (spanningForest LabeledUndirected, dist float64) {
+	return g.WeightedArcsAsEdges(w).Boruvka()
+}
+
+// Boruvka implements Borůvka's algorithm for constructing a minimum spanning
+// forest on an undirected graph.
+//
+// In each round of the algorithm, every component of the forest so far
+// selects its minimum weight edge to another component and all selected
+// edges are added.  The number of components at least halves each round.
+// The selection of minimum edges is done in parallel across goroutines,
+// making the method suitable for large graphs on multicore machines.
+// The WeightFunc of the receiver must be safe for concurrent use.
+//
+// Where edges have equal weights, they are ordered by position in the edge
+// list.  As with Kruskal, parallel edges are allowed.  The receiver is not
+// modified.  Return values are as for Kruskal.
+func (l WeightedEdgeList) Boruvka() (g LabeledUndirected, dist float64) {
+	const chunk = 1024 // minimum number of edges per worker
+	edges := l.Edges
+	w := l.WeightFunc
+	g.LabeledAdjacencyList = make(LabeledAdjacencyList, l.Order)
+	ds := NewDisjointSet(l.Order)
+	// live holds indexes of edges between distinct components.  It starts
+	// with all edges.  Loops and edges that have come within a component
+	// are dropped at the start of each round.
+	live := make([]int32, len(edges))
+	for i := range live {
+		live[i] = int32(i)
+	}
+	// less orders edges by weight, then by index
+	less := func(x, y int32) bool {
+		wx, wy := w(edges[x].LI), w(edges[y].LI)
+		return wx < wy || wx == wy && x < y
+	}
+	// Each round, components with live edges are numbered 0 to k-1, so that
+	// per-round state is sized by the number of components remaining rather
+	// than by the order of the graph.  cid holds the number of component
+	// roots stamped with the current round.
+	cid := make([]int32, l.Order)
+	stamp := make([]int32, l.Order)
+	var c1, c2 []int32 // component numbers of the ends of live edges
+	for round := int32(1); ; round++ {
+		k := int32(0)
+		num := func(r NI) int32 {
+			if stamp[r] != round {
+				stamp[r] = round
+				cid[r] = k
+				k++
+			}
+			return cid[r]
+		}
+		kept := live[:0]
+		c1, c2 = c1[:0], c2[:0]
+		for _, x := range live {
+			e := edges[x]
+			if r1, r2 := ds.Find(e.N1), ds.Find(e.N2); r1 != r2 {
+				kept = append(kept, x)
+				c1 = append(c1, num(r1))
+				c2 = append(c2, num(r2))
+			}
+		}
+		live = kept
+		if len(live) == 0 {
+			return
+		}
+		nw := runtime.GOMAXPROCS(0)
+		if m := 1 + len(live)/chunk; m < nw {
+			nw = m
+		}
+		// parallel runs f on nw chunks of range [0, n)
+		parallel := func(n int, f func(wk, lo, hi int)) {
+			var wg sync.WaitGroup
+			for wk := 0; wk < nw; wk++ {
+				lo, hi := n*wk/nw, n*(wk+1)/nw
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					f(wk, lo, hi)
+				}()
+			}
+			wg.Wait()
+		}
+		// best edge for each component, by worker
+		buf := make([]int32, nw*int(k))
+		best := make([][]int32, nw)
+		for wk := range best {
+			best[wk] = buf[wk*int(k) : (wk+1)*int(k)]
+		}
+		// each worker finds best edges over its part of the edge list
+		parallel(len(live), func(wk, lo, hi int) {
+			b := best[wk]
+			for c := range b {
+				b[c] = -1
+			}
+			for i := lo; i < hi; i++ {
+				x := live[i]
+				for _, c := range [2]int32{c1[i], c2[i]} {
+					if b[c] < 0 || less(x, b[c]) {
+						b[c] = x
+					}
+				}
+			}
+		})
+		// combine worker results, in parallel over components
+		parallel(int(k), func(_, lo, hi int) {
+			b0 := best[0]
+			for _, b := range best[1:] {
+				for c := lo; c < hi; c++ {
+					if x := b[c]; x >= 0 && (b0[c] < 0 || less(x, b0[c])) {
+						b0[c] = x
+					}
+				}
+			}
+		})
+		// every numbered component has a live edge and so a best edge.
+		for _, x := range best[0] {
+			e := edges[x]
+			if ds.Union(e.N1, e.N2) {
+				g.AddEdge(e.Edge, e.LI)
+				dist += w(e.LI)
+			}
 		}
 	}
+}
+
+// FilterKruskal implements the Filter-Kruskal algorithm for constructing a
+// minimum spanning forest on an undirected graph.
+//
+// Filter-Kruskal avoids sorting the full edge list.  It partitions edges
+// around a pivot weight, as in quicksort, and processes lighter edges
+// first.  Heavier edges are then filtered to remove those connecting nodes
+// already connected, before they are partitioned further.  For graphs where
+// most edges are not needed in the forest this saves much of the sorting
+// work of Kruskal.
+//
+// As with Kruskal, parallel edges are allowed.  The edge list of the
+// receiver is reordered in place as a side effect of this method.  Return
+// values are as for Kruskal.
+func (l WeightedEdgeList) FilterKruskal() (g LabeledUndirected, dist float64) {
+	const threshold = 32 // below this size, sort and run Kruskal
+	w := l.WeightFunc
+	ds := NewDisjointSet(l.Order)
+	g.LabeledAdjacencyList = make(LabeledAdjacencyList, l.Order)
+	kruskal := func(edges []LabeledEdge) {
+		for _, e := range edges {
+			if ds.Union(e.N1, e.N2) {
+				g.AddEdge(e.Edge, e.LI)
+				dist += w(e.LI)
+			}
+		}
+	}
+	var fk func([]LabeledEdge)
+	fk = func(edges []LabeledEdge) {
+		if len(edges) <= threshold {
+			sort.Slice(edges, func(i, j int) bool {
+				return w(edges[i].LI) < w(edges[j].LI)
+			})
+			kruskal(edges)
+			return
+		}
+		// median of three pivot
+		a := w(edges[0].LI)
+		b := w(edges[len(edges)/2].LI)
+		c := w(edges[len(edges)-1].LI)
+		if a > b {
+			a, b = b, a
+		}
+		if b > c {
+			b = c
+		}
+		if a > b {
+			b = a
+		}
+		pivot := b
+		// three way partition: < pivot, == pivot, > pivot
+		lt, i, gt := 0, 0, len(edges)
+		for i < gt {
+			switch wt := w(edges[i].LI); {
+			case wt < pivot:
+				edges[lt], edges[i] = edges[i], edges[lt]
+				lt++
+				i++
+			case wt > pivot:
+				gt--
+				edges[gt], edges[i] = edges[i], edges[gt]
+			default:
+				i++
+			}
+		}
+		fk(edges[:lt])
+		kruskal(edges[lt:gt])
+		// filter heavier edges
+		heavy := edges[gt:]
+		k := 0
+		for _, e := range heavy {
+			if !ds.Same(e.N1, e.N2) {
+				heavy[k] = e
+				k++
+			}
+		}
+		fk(heavy[:k])
+	}
+	fk(l.Edges)
 	return
 }
 
@@ -160,7 +330,18 @@ func (l WeightedEdgeList) KruskalSorted() (g LabeledUndirected, dist float64) {
 // Returned are the number of nodes spanned for the single tree (which will be
 // the order of the connected component) and the total spanned distance for the
 // single tree.
+//
+// This method is a wrapper for the generic function Prim.
 func (g LabeledUndirected) Prim(start NI, w WeightFunc, f *FromList, labels []LI, componentLeaves *bits.Bits) (numSpanned int, dist float64) {
+	return Prim(g, start, w, f, labels, componentLeaves)
+}
+
+// Prim implements the Jarník-Prim-Dijkstra algorithm for constructing
+// a minimum spanning tree on an undirected graph.
+//
+// It is the generic version of LabeledUndirected.Prim, accepting any Weight
+// type.  See the method for documentation.
+func Prim[W Weight](g LabeledUndirected, start NI, w func(LI) W, f *FromList, labels []LI, componentLeaves *bits.Bits) (numSpanned int, dist W) {
 	al := g.LabeledAdjacencyList
 	if len(f.Paths) != len(al) {
 		*f = NewFromList(len(al))
@@ -168,13 +349,13 @@ func (g LabeledUndirected) Prim(start NI, w WeightFunc, f *FromList, labels []LI
 	if f.Leaves.Num != len(al) {
 		f.Leaves = bits.New(len(al))
 	}
-	b := make([]prNode, len(al)) // "best"
+	b := make([]prNode[W], len(al)) // "best"
 	for n := range b {
 		b[n].nx = NI(n)
 		b[n].fx = -1
 	}
 	rp := f.Paths
-	var frontier prHeap
+	var frontier prHeap[W]
 	rp[start] = PathEnd{From: -1, Len: 1}
 	numSpanned = 1
 	fLeaves := &f.Leaves
@@ -204,7 +385,7 @@ func (g LabeledUndirected) Prim(start NI, w WeightFunc, f *FromList, labels []LI
 		if len(frontier) == 0 {
 			break // done
 		}
-		bp := heap.Pop(&frontier).(*prNode)
+		bp := heap.Pop(&frontier).(*prNode[W])
 		a = bp.nx
 		rp[a].Len = rp[bp.from.From].Len + 1
 		rp[a].From = bp.from.From
@@ -233,30 +414,173 @@ type fromHalf struct {
 	Label LI
 }
 
-type prNode struct {
+type prNode[W Weight] struct {
 	nx   NI
 	from fromHalf
-	wt   float64 // p.Weight(from.Label)
+	wt   W // p.Weight(from.Label)
 	fx   int
 }
 
-type prHeap []*prNode
+type prHeap[W Weight] []*prNode[W]
 
-func (h prHeap) Len() int           { return len(h) }
-func (h prHeap) Less(i, j int) bool { return h[i].wt < h[j].wt }
-func (h prHeap) Swap(i, j int) {
+func (h prHeap[W]) Len() int           { return len(h) }
+func (h prHeap[W]) Less(i, j int) bool { return h[i].wt < h[j].wt }
+func (h prHeap[W]) Swap(i, j int) {
 	h[i], h[j] = h[j], h[i]
 	h[i].fx = i
 	h[j].fx = j
 }
-func (p *prHeap) Push(x interface{}) {
-	nd := x.(*prNode)
+func (p *prHeap[W]) Push(x interface{}) {
+	nd := x.(*prNode[W])
 	nd.fx = len(*p)
 	*p = append(*p, nd)
 }
-func (p *prHeap) Pop() interface{} {
+func (p *prHeap[W]) Pop() interface{} {
 	r := *p
 	last := len(r) - 1
 	*p = r[:last]
 	return r[last]
 }
+
+// Arborescence constructs a minimum spanning arborescence of a directed
+// graph by the algorithm of Chu, Liu, and Edmonds.
+//
+// An arborescence rooted at root is a spanning tree with arcs directed away
+// from root, so that there is exactly one path from root to every node.  The
+// minimum arborescence is one with minimum total arc weight.  Arc weights
+// are obtained from arc labels by w and may be negative.  Parallel arcs and
+// loops are allowed.
+//
+// If every node of g is reachable from root, Arborescence returns the
+// arborescence as a FromList f, labels of the arcs, and the total cost.
+// Labels[n] is the label of the arc from f.Paths[n].From to n, as with
+// FromListLabels.  Labels[root] is -1.  All members of the FromList are
+// populated.
+//
+// If some node is not reachable from root, Arborescence returns an error.
+func (g LabeledDirected) Arborescence(root NI, w WeightFunc) (f FromList, labels []LI, cost float64, err error) {
+	a := g.LabeledAdjacencyList
+	var bf FromList
+	a.BreadthFirst(root, From(&bf))
+	for n, e := range bf.Paths {
+		if e.Len == 0 {
+			return f, nil, 0, fmt.Errorf("node %d not reachable from root %d", n, root)
+		}
+	}
+	var arcs []arbArc
+	for fr, to := range a {
+		for _, to := range to {
+			if to.To != NI(fr) && to.To != root {
+				arcs = append(arcs, arbArc{NI(fr), to.To, w(to.Label), to.Label, len(arcs)})
+			}
+		}
+	}
+	f = NewFromList(len(a))
+	labels = make([]LI, len(a))
+	f.Paths[root].From = -1
+	labels[root] = -1
+	for _, x := range arborescence(len(a), root, arcs) {
+		e := arcs[x]
+		f.Paths[e.to].From = e.fr
+		labels[e.to] = e.l
+		cost += e.w
+	}
+	f.RecalcLeaves()
+	f.RecalcLen()
+	return
+}
+
+// arbArc is an arc for the function arborescence.
+type arbArc struct {
+	fr, to NI
+	w      float64
+	l      LI  // label of the original arc
+	x      int // index into the arc list of the uncontracted graph
+}
+
+// arborescence returns indexes into arcs of a minimum arborescence of the
+// graph of n nodes.
+//
+// Every node must be reachable from root, and arcs must not include loops
+// or arcs to root.
+func arborescence(n int, root NI, arcs []arbArc) []int {
+	// minimum incoming arc for each node
+	in := make([]int, n)
+	for i := range in {
+		in[i] = -1
+	}
+	for x, e := range arcs {
+		if m := in[e.to]; m < 0 || e.w < arcs[m].w {
+			in[e.to] = x
+		}
+	}
+	// find cycles of minimum incoming arcs, numbering them as contracted
+	// nodes.  comp is the contracted node of each node.
+	comp := make([]NI, n)
+	mark := make([]int, n) // node of the walk that reached each node
+	for i := range comp {
+		comp[i] = -1
+		mark[i] = -1
+	}
+	nc := 0
+	cyclic := false
+	for v := range comp {
+		u := NI(v)
+		for u != root && mark[u] < 0 && comp[u] < 0 {
+			mark[u] = v
+			u = arcs[in[u]].fr
+		}
+		if u != root && mark[u] == v && comp[u] < 0 {
+			// found a new cycle through u
+			cyclic = true
+			for c := u; comp[c] < 0; c = arcs[in[c]].fr {
+				comp[c] = NI(nc)
+			}
+			nc++
+		}
+	}
+	if !cyclic {
+		r := make([]int, 0, n-1)
+		for v, x := range in {
+			if NI(v) != root {
+				r = append(r, x)
+			}
+		}
+		return r
+	}
+	inCycle := make([]bool, n)
+	for v, c := range comp {
+		if c >= 0 {
+			inCycle[v] = true
+		} else {
+			comp[v] = NI(nc)
+			nc++
+		}
+	}
+	// contract cycles, adjusting weights of arcs entering cycles
+	var ca []arbArc
+	for x, e := range arcs {
+		cf, ct := comp[e.fr], comp[e.to]
+		if cf == ct {
+			continue
+		}
+		wt := e.w
+		if inCycle[e.to] {
+			wt -= arcs[in[e.to]].w
+		}
+		ca = append(ca, arbArc{cf, ct, wt, e.l, x})
+	}
+	var r []int
+	entered := make([]bool, n) // nodes of cycles entered by a chosen arc
+	for _, cx := range arborescence(nc, comp[root], ca) {
+		x := ca[cx].x
+		r = append(r, x)
+		entered[arcs[x].to] = true
+	}
+	for v, x := range in {
+		if inCycle[v] && !entered[v] {
+			r = append(r, x)
+		}
+	}
+	return r
+}
@@ -5,6 +5,7 @@ package graph_test
 
 import (
 	"fmt"
+	"math/rand"
 	"testing"
 
 	"github.com/soniakeys/bits"
@@ -84,6 +85,38 @@ func ExampleWeightedEdgeList_Kruskal() {
 	// total distance:  110
 }
 
+func ExampleKruskal() {
+	//       (10)
+	//     0------4----\
+	//     |     /|     \(70)
+	// (30)| (40) |(60)  \
+	//     |/     |      |
+	//     1------2------3
+	//       (50)   (20)
+	edges := []graph.LabeledEdge{
+		{graph.Edge{0, 1}, 30},
+		{graph.Edge{0, 4}, 10},
+		{graph.Edge{1, 2}, 50},
+		{graph.Edge{1, 4}, 40},
+		{graph.Edge{2, 3}, 20},
+		{graph.Edge{2, 4}, 60},
+		{graph.Edge{3, 4}, 70},
+	}
+	// integer weights
+	t, dist := graph.Kruskal(5, edges, func(l graph.LI) int { return int(l) })
+	for n, to := range t.LabeledAdjacencyList {
+		fmt.Println(n, to)
+	}
+	fmt.Println("total distance: ", dist)
+	// Output:
+	// 0 [{4 10} {1 30}]
+	// 1 [{0 30} {2 50}]
+	// 2 [{3 20} {1 50}]
+	// 3 [{2 20}]
+	// 4 [{0 10}]
+	// total distance:  110
+}
+
 func ExampleWeightedEdgeList_Kruskal_fromUndirected() {
 	//       (10)
 	//     0------4----\
@@ -256,4 +289,234 @@ func TestPrim100(t *testing.T) {
 			t.Fatal("Not all nodes spanned within a connected component.")
 		}
 	}
+	// generic version with weights scaled to integers
+	wn := func(l graph.LI) int64 { return int64(r100.w[l] * 1e9) }
+	var fn graph.FromList
+	var dp int64
+	for _, r := range reps {
+		_, d := graph.Prim(u100, r, wn, &fn, nil, nil)
+		dp += d
+	}
+	_, dk := graph.Kruskal(u100.Order(), u100.WeightedArcsAsEdges(w).Edges, wn)
+	if dp != dk {
+		t.Fatal("generic Prim distance", dp, "Kruskal", dk)
+	}
+}
+
+func ExampleLabeledUndirected_Boruvka() {
+	//       (10)
+	//     0------4----\
+	//     |     /|     \(70)
+	// (30)| (40) |(60)  \
+	//     |/     |      |
+	//     1------2------3
+	//       (50)   (20)
+	w := func(l graph.LI) float64 { return float64(l) }
+	var g graph.LabeledUndirected
+	g.AddEdge(graph.Edge{0, 1}, 30)
+	g.AddEdge(graph.Edge{0, 4}, 10)
+	g.AddEdge(graph.Edge{1, 2}, 50)
+	g.AddEdge(graph.Edge{1, 4}, 40)
+	g.AddEdge(graph.Edge{2, 3}, 20)
+	g.AddEdge(graph.Edge{2, 4}, 60)
+	g.AddEdge(graph.Edge{3, 4}, 70)
+
+	t, dist := g.Boruvka(w)
+	for n, to := range t.LabeledAdjacencyList {
+		fmt.Println(n, to)
+	}
+	fmt.Println("total distance: ", dist)
+	// Output:
+	// 0 [{4 10} {1 30}]
+	// 1 [{0 30} {2 50}]
+	// 2 [{3 20} {1 50}]
+	// 3 [{2 20}]
+	// 4 [{0 10}]
+	// total distance:  110
+}
+
+func ExampleWeightedEdgeList_FilterKruskal() {
+	//       (10)
+	//     0------4----\
+	//     |     /|     \(70)
+	// (30)| (40) |(60)  \
+	//     |/     |      |
+	//     1------2------3
+	//       (50)   (20)
+	l := graph.WeightedEdgeList{
+		Order:      5,
+		WeightFunc: func(l graph.LI) float64 { return float64(l) },
+		Edges: []graph.LabeledEdge{
+			{graph.Edge{0, 1}, 30},
+			{graph.Edge{0, 4}, 10},
+			{graph.Edge{1, 2}, 50},
+			{graph.Edge{1, 4}, 40},
+			{graph.Edge{2, 3}, 20},
+			{graph.Edge{2, 4}, 60},
+			{graph.Edge{3, 4}, 70},
+		},
+	}
+	t, dist := l.FilterKruskal()
+	for n, to := range t.LabeledAdjacencyList {
+		fmt.Println(n, to)
+	}
+	fmt.Println("total distance: ", dist)
+	// Output:
+	// 0 [{4 10} {1 30}]
+	// 1 [{0 30} {2 50}]
+	// 2 [{3 20} {1 50}]
+	// 3 [{2 20}]
+	// 4 [{0 10}]
+	// total distance:  110
+}
+
+func TestBoruvkaFilterKruskal(t *testing.T) {
+	r := rand.New(rand.NewSource(48))
+	for _, n := range []int{1, 10, 100, 1000, 10000} {
+		g := graph.GnmUndirected(n, n*3/2, r)
+		var lg graph.LabeledUndirected
+		lg.LabeledAdjacencyList = make(graph.LabeledAdjacencyList, n)
+		var w []float64
+		g.SimpleEdges(func(e graph.Edge) {
+			lg.AddEdge(e, graph.LI(len(w)))
+			// small integer weights to exercise ties
+			w = append(w, float64(r.Intn(20)))
+		})
+		// loops, lighter than any edge, must not be selected
+		for i := 0; i < n/10+1; i++ {
+			x := graph.NI(r.Intn(n))
+			lg.AddEdge(graph.Edge{x, x}, graph.LI(len(w)))
+			w = append(w, -1)
+		}
+		wf := func(l graph.LI) float64 { return w[l] }
+		_, want := lg.Kruskal(wf)
+		check := func(name string, f graph.LabeledUndirected, dist float64) {
+			if dist != want {
+				t.Fatal(name, n, "dist", dist, "want", want)
+			}
+			c := graph.NewConnectivityUndirected(g)
+			fc := graph.NewConnectivityUndirected(
+				graph.Undirected{f.LabeledAdjacencyList.Unlabeled()})
+			if c.NumComponents() != fc.NumComponents() {
+				t.Fatal(name, n, "not spanning")
+			}
+			if f.Size() != n-c.NumComponents() {
+				t.Fatal(name, n, "not a forest")
+			}
+		}
+		f, dist := lg.Boruvka(wf)
+		check("Boruvka", f, dist)
+		f, dist = lg.WeightedArcsAsEdges(wf).FilterKruskal()
+		check("FilterKruskal", f, dist)
+	}
+}
+
+func ExampleLabeledDirected_Arborescence() {
+	//      0
+	//   5 / \ 1
+	//    v   v
+	//    1<-2-2
+	//    |   ^
+	//  1 \   / 1  (3->2)
+	//     v /
+	//      3
+	g := graph.LabeledDirected{graph.LabeledAdjacencyList{
+		0: {{To: 1, Label: 5}, {To: 2, Label: 1}},
+		1: {{To: 3, Label: 1}},
+		2: {{To: 1, Label: 2}},
+		3: {{To: 2, Label: 1}},
+	}}
+	w := func(l graph.LI) float64 { return float64(l) }
+	f, labels, cost, err := g.Arborescence(0, w)
+	fmt.Println(err)
+	for n, e := range f.Paths {
+		fmt.Println(n, e.From, labels[n])
+	}
+	fmt.Println("cost:", cost)
+	// Output:
+	// <nil>
+	// 0 -1 -1
+	// 1 2 2
+	// 2 0 1
+	// 3 1 1
+	// cost: 4
+}
+
+func TestArborescence(t *testing.T) {
+	r := rand.New(rand.NewSource(49))
+	for tc := 0; tc < 200; tc++ {
+		n := 1 + r.Intn(6)
+		g := graph.LabeledDirected{make(graph.LabeledAdjacencyList, n)}
+		var w []float64
+		for i := r.Intn(3 * n); i >= 0; i-- {
+			fr := r.Intn(n)
+			g.LabeledAdjacencyList[fr] = append(g.LabeledAdjacencyList[fr],
+				graph.Half{To: graph.NI(r.Intn(n)), Label: graph.LI(len(w))})
+			w = append(w, float64(r.Intn(21)-5))
+		}
+		wf := func(l graph.LI) float64 { return w[l] }
+		// brute force: try every choice of an incoming arc for each node
+		tr, _ := g.Transpose()
+		best, found := 0., false
+		choice := make([]int, n)
+		var try func(v int)
+		try = func(v int) {
+			if v == n {
+				p := make([]graph.PathEnd, n)
+				c := 0.
+				for u := range p {
+					p[u].From = -1
+					if u > 0 {
+						h := tr.LabeledAdjacencyList[u][choice[u]]
+						p[u].From = h.To
+						c += w[h.Label]
+					}
+				}
+				if cyc, _ := (graph.FromList{Paths: p}).Cyclic(); !cyc &&
+					(!found || c < best) {
+					best, found = c, true
+				}
+				return
+			}
+			if v == 0 {
+				try(1)
+				return
+			}
+			for x := range tr.LabeledAdjacencyList[v] {
+				choice[v] = x
+				try(v + 1)
+			}
+		}
+		try(0)
+		f, labels, cost, err := g.Arborescence(0, wf)
+		if !found {
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cost != best {
+			t.Fatal("cost", cost, "want", best)
+		}
+		// result must be a valid arborescence of the claimed cost
+		c := 0.
+		for v, e := range f.Paths {
+			if v == 0 {
+				if e.From != -1 || e.Len != 1 {
+					t.Fatal("root")
+				}
+				continue
+			}
+			if ok, _ := g.HasArcLabel(e.From, graph.NI(v), labels[v]); !ok {
+				t.Fatal("arc", e.From, v, labels[v])
+			}
+			c += w[labels[v]]
+		}
+		if cyc, _ := f.Cyclic(); cyc || c != cost {
+			t.Fatal("invalid arborescence")
+		}
+	}
 }
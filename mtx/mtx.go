@@ -0,0 +1,339 @@
+// Copyright 2026 Sonia Keys
+// License MIT: https://opensource.org/licenses/MIT
+
+// Package mtx reads and writes graphs in the Matrix Market exchange format.
+//
+// The format, described at http://math.nist.gov/MatrixMarket/formats.html,
+// stores sparse matrices as a list of coordinate entries.  A square sparse
+// matrix is read as a graph where a nonzero entry at row i, column j
+// represents an arc from node i to node j.  Matrix Market files number rows
+// and columns from 1.  Row i of a file becomes graph.NI i-1.  Readers
+// accept matrix sizes up to MaxOrder.
+//
+// Supported are coordinate matrices with fields real, integer, or pattern,
+// and symmetry general, symmetric, or skew-symmetric.  Complex and hermitian
+// matrices and dense array format are not supported.
+//
+// Like package dot, mtx is a separate package from graph.  It imports
+// graph; graph knows nothing of mtx.
+package mtx
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/soniakeys/graph"
+)
+
+// MaxOrder is the maximum matrix size accepted from a size line.
+//
+// Rows and columns without entries take no lines of a Matrix Market file,
+// so a short file can declare a matrix of any size.  Readers return an
+// error for a size greater than MaxOrder rather than allocate a graph of
+// that order.  Set MaxOrder higher to read larger matrices, or lower when
+// reading untrusted input.
+var MaxOrder = 1 << 25
+
+// header holds values parsed from the banner and size lines.
+type header struct {
+	pattern  bool
+	symmetry string
+	n, nnz   int
+}
+
+// scanner wraps a bufio.Scanner, tracking line numbers and skipping
+// comments.
+type scanner struct {
+	*bufio.Scanner
+	line   int
+	fields []string
+}
+
+func (s *scanner) next() bool {
+	for s.Scan() {
+		s.line++
+		t := s.Text()
+		if strings.HasPrefix(t, "%") {
+			continue
+		}
+		if s.fields = strings.Fields(t); len(s.fields) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *scanner) errorf(format string, a ...interface{}) error {
+	return fmt.Errorf("mtx: line %d: %s", s.line, fmt.Sprintf(format, a...))
+}
+
+// readHeader reads the banner and size lines.
+func (s *scanner) readHeader() (h header, err error) {
+	if !s.Scan() {
+		if err = s.Err(); err == nil {
+			err = errors.New("mtx: missing header")
+		}
+		return
+	}
+	s.line++
+	f := strings.Fields(strings.ToLower(s.Text()))
+	if len(f) != 5 || f[0] != "%%matrixmarket" || f[1] != "matrix" {
+		err = s.errorf("invalid header")
+		return
+	}
+	if f[2] != "coordinate" {
+		err = s.errorf("unsupported format %q", f[2])
+		return
+	}
+	switch f[3] {
+	case "real", "integer":
+	case "pattern":
+		h.pattern = true
+	default:
+		err = s.errorf("unsupported field %q", f[3])
+		return
+	}
+	switch f[4] {
+	case "general", "symmetric", "skew-symmetric":
+		h.symmetry = f[4]
+	default:
+		err = s.errorf("unsupported symmetry %q", f[4])
+		return
+	}
+	if !s.next() {
+		if err = s.Err(); err == nil {
+			err = errors.New("mtx: missing size line")
+		}
+		return
+	}
+	if len(s.fields) != 3 {
+		err = s.errorf("invalid size line")
+		return
+	}
+	var v [3]int
+	for i, f := range s.fields {
+		// sizes must fit graph.NI and graph.LI
+		if v[i], err = strconv.Atoi(f); err != nil ||
+			v[i] < 0 || v[i] > math.MaxInt32 {
+			err = s.errorf("invalid size %q", f)
+			return
+		}
+	}
+	if v[0] != v[1] {
+		err = s.errorf("matrix not square")
+		return
+	}
+	if v[0] > MaxOrder {
+		err = s.errorf("size %d exceeds MaxOrder %d", v[0], MaxOrder)
+		return
+	}
+	h.n, h.nnz = v[0], v[2]
+	return
+}
+
+// entries reads the coordinate entries of the matrix, calling f for each.
+func (s *scanner) entries(h header, f func(i, j graph.NI, v float64) error) error {
+	want := 3
+	if h.pattern {
+		want = 2
+	}
+	for k := 0; k < h.nnz; k++ {
+		if !s.next() {
+			if err := s.Err(); err != nil {
+				return err
+			}
+			return fmt.Errorf("mtx: found %d entries, header specified %d",
+				k, h.nnz)
+		}
+		if len(s.fields) != want {
+			return s.errorf("expected %d fields", want)
+		}
+		var ij [2]graph.NI
+		for x := range ij {
+			n, err := strconv.Atoi(s.fields[x])
+			if err != nil || n < 1 || n > h.n {
+				return s.errorf("invalid index %q", s.fields[x])
+			}
+			ij[x] = graph.NI(n - 1)
+		}
+		i, j := ij[0], ij[1]
+		if h.symmetry != "general" {
+			if j > i {
+				return s.errorf("entry above diagonal in %s matrix",
+					h.symmetry)
+			}
+			if j == i && h.symmetry == "skew-symmetric" {
+				return s.errorf("diagonal entry in skew-symmetric matrix")
+			}
+		}
+		v := 1.
+		if !h.pattern {
+			var err error
+			if v, err = strconv.ParseFloat(s.fields[2], 64); err != nil {
+				return s.errorf("invalid value %q", s.fields[2])
+			}
+		}
+		if err := f(i, j, v); err != nil {
+			return err
+		}
+	}
+	if s.next() {
+		return s.errorf("extra entry")
+	}
+	return s.Err()
+}
+
+// extend extends s as needed to include index n.
+//
+// Readers allocate nodes only as entries reference them, and then extend to
+// the order of the header after all entries have been read.  Truncated or
+// corrupt input thus fails before a large allocation, and the order itself
+// is bounded by MaxOrder.
+func extend[S ~[]E, E any](s S, n graph.NI) S {
+	if int(n) >= len(s) {
+		s = append(s, make(S, int(n)+1-len(s))...)
+	}
+	return s
+}
+
+// ReadDirected reads a square matrix as a directed graph.
+//
+// An entry at row i, column j becomes an arc from i-1 to j-1.  For symmetric
+// and skew-symmetric matrices, off-diagonal entries become two arcs, the
+// second with the same or negated value respectively.
+//
+// Arc labels number the arcs from 0 in the order created.  Returned slice
+// wt is indexed by label and holds the matrix values.  It is nil for
+// pattern matrices.
+func ReadDirected(r io.Reader) (g graph.LabeledDirected, wt []float64, err error) {
+	s := &scanner{Scanner: bufio.NewScanner(r)}
+	h, err := s.readHeader()
+	if err != nil {
+		return
+	}
+	var a graph.LabeledAdjacencyList
+	var w []float64
+	add := func(i, j graph.NI, v float64) {
+		a = extend(a, i)
+		a[i] = append(a[i], graph.Half{To: j, Label: graph.LI(len(w))})
+		w = append(w, v)
+	}
+	if err = s.entries(h, func(i, j graph.NI, v float64) error {
+		add(i, j, v)
+		switch {
+		case i == j:
+		case h.symmetry == "symmetric":
+			add(j, i, v)
+		case h.symmetry == "skew-symmetric":
+			add(j, i, -v)
+		}
+		return nil
+	}); err != nil {
+		return
+	}
+	g.LabeledAdjacencyList = extend(a, graph.NI(h.n-1))
+	if !h.pattern {
+		wt = w
+	}
+	return
+}
+
+// ReadUndirected reads a symmetric matrix as an undirected graph.
+//
+// The matrix must be declared symmetric.  Each entry at row i, column j
+// becomes an edge between i-1 and j-1.  Diagonal entries become loops.
+//
+// Edge labels number the edges from 0 in the order created, with reciprocal
+// arcs sharing a label.  Returned slice wt is indexed by label and holds the
+// matrix values.  It is nil for pattern matrices.
+func ReadUndirected(r io.Reader) (g graph.LabeledUndirected, wt []float64, err error) {
+	s := &scanner{Scanner: bufio.NewScanner(r)}
+	h, err := s.readHeader()
+	if err != nil {
+		return
+	}
+	if h.symmetry != "symmetric" {
+		return g, nil, fmt.Errorf("mtx: %s matrix not undirected", h.symmetry)
+	}
+	var w []float64
+	if err = s.entries(h, func(i, j graph.NI, v float64) error {
+		// i >= j, as checked by entries
+		g.LabeledAdjacencyList = extend(g.LabeledAdjacencyList, i)
+		g.AddEdge(graph.Edge{i, j}, graph.LI(len(w)))
+		w = append(w, v)
+		return nil
+	}); err != nil {
+		return
+	}
+	g.LabeledAdjacencyList = extend(g.LabeledAdjacencyList, graph.NI(h.n-1))
+	if !h.pattern {
+		wt = w
+	}
+	return
+}
+
+// fmtWeight formats a weight without an exponent.
+func fmtWeight(w float64) string {
+	return strconv.FormatFloat(w, 'f', -1, 64)
+}
+
+// write writes a header and the entries of g selected by ok.
+func write(wr io.Writer, g graph.LabeledAdjacencyList, w graph.WeightFunc, symmetry string, ok func(fr, to graph.NI) bool) error {
+	nnz := 0
+	for fr, to := range g {
+		for _, to := range to {
+			if ok(graph.NI(fr), to.To) {
+				nnz++
+			}
+		}
+	}
+	field := "real"
+	if w == nil {
+		field = "pattern"
+	}
+	b := bufio.NewWriter(wr)
+	_, err := fmt.Fprintf(b, "%%%%MatrixMarket matrix coordinate %s %s\n%d %d %d\n",
+		field, symmetry, len(g), len(g), nnz)
+	if err != nil {
+		return err
+	}
+	for fr, to := range g {
+		for _, to := range to {
+			if !ok(graph.NI(fr), to.To) {
+				continue
+			}
+			b.WriteString(strconv.Itoa(fr+1) + " " + strconv.Itoa(int(to.To)+1))
+			if w != nil {
+				b.WriteString(" " + fmtWeight(w(to.Label)))
+			}
+			if _, err := b.WriteString("\n"); err != nil {
+				return err
+			}
+		}
+	}
+	return b.Flush()
+}
+
+// WriteDirected writes a directed graph as a general matrix.
+//
+// Each arc becomes an entry with the arc weight as the value.  If w is nil
+// the matrix is written as a pattern matrix.
+func WriteDirected(wr io.Writer, g graph.LabeledDirected, w graph.WeightFunc) error {
+	return write(wr, g.LabeledAdjacencyList, w, "general",
+		func(graph.NI, graph.NI) bool { return true })
+}
+
+// WriteUndirected writes an undirected graph as a symmetric matrix.
+//
+// Each edge becomes a single entry in the lower triangle of the matrix with
+// the edge weight as the value.  If w is nil the matrix is written as a
+// pattern matrix.
+func WriteUndirected(wr io.Writer, g graph.LabeledUndirected, w graph.WeightFunc) error {
+	return write(wr, g.LabeledAdjacencyList, w, "symmetric",
+		func(fr, to graph.NI) bool { return fr >= to })
+}
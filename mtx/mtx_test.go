@@ -0,0 +1,191 @@
+// Copyright 2026 Sonia Keys
+// License MIT: https://opensource.org/licenses/MIT
+
+package mtx_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/soniakeys/graph"
+	"github.com/soniakeys/graph/mtx"
+)
+
+func ExampleReadDirected() {
+	f := `%%MatrixMarket matrix coordinate real general
+% a 3x3 matrix
+3 3 4
+1 2 1.5
+1 3 2
+2 3 -1
+3 1 4
+`
+	g, wt, err := mtx.ReadDirected(strings.NewReader(f))
+	fmt.Println(err)
+	for fr, to := range g.LabeledAdjacencyList {
+		fmt.Println(fr, to)
+	}
+	fmt.Println(wt)
+	// Output:
+	// <nil>
+	// 0 [{1 0} {2 1}]
+	// 1 [{2 2}]
+	// 2 [{0 3}]
+	// [1.5 2 -1 4]
+}
+
+func ExampleReadUndirected() {
+	f := `%%MatrixMarket matrix coordinate pattern symmetric
+3 3 3
+2 1
+3 1
+3 2
+`
+	g, wt, err := mtx.ReadUndirected(strings.NewReader(f))
+	fmt.Println(err)
+	for fr, to := range g.LabeledAdjacencyList {
+		fmt.Println(fr, to)
+	}
+	fmt.Println(wt == nil)
+	// Output:
+	// <nil>
+	// 0 [{1 0} {2 1}]
+	// 1 [{0 0} {2 2}]
+	// 2 [{0 1} {1 2}]
+	// true
+}
+
+func ExampleWriteDirected() {
+	g := graph.LabeledDirected{graph.LabeledAdjacencyList{
+		0: {{To: 1, Label: 0}, {To: 2, Label: 1}},
+		1: {{To: 2, Label: 2}},
+		2: nil,
+	}}
+	wt := []float64{1.5, 2, 3}
+	mtx.WriteDirected(os.Stdout, g, func(l graph.LI) float64 { return wt[l] })
+	// Output:
+	// %%MatrixMarket matrix coordinate real general
+	// 3 3 3
+	// 1 2 1.5
+	// 1 3 2
+	// 2 3 3
+}
+
+func ExampleWriteUndirected() {
+	// 0---1
+	//  \ /
+	//   2
+	var g graph.LabeledUndirected
+	g.AddEdge(graph.Edge{0, 1}, 0)
+	g.AddEdge(graph.Edge{0, 2}, 1)
+	g.AddEdge(graph.Edge{1, 2}, 2)
+	mtx.WriteUndirected(os.Stdout, g, nil)
+	// Output:
+	// %%MatrixMarket matrix coordinate pattern symmetric
+	// 3 3 3
+	// 2 1
+	// 3 1
+	// 3 2
+}
+
+func TestRoundTrip(t *testing.T) {
+	g, _, wt, err := graph.LabeledEuclidean(100, 400, 1, 10, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := func(l graph.LI) float64 { return wt[l] }
+	var b bytes.Buffer
+	if err := mtx.WriteDirected(&b, g, w); err != nil {
+		t.Fatal(err)
+	}
+	g2, wt2, err := mtx.ReadDirected(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for fr, to := range g.LabeledAdjacencyList {
+		to2 := g2.LabeledAdjacencyList[fr]
+		if len(to2) != len(to) {
+			t.Fatal("arc count mismatch at node", fr)
+		}
+		for x, h := range to {
+			if to2[x].To != h.To || wt2[to2[x].Label] != wt[h.Label] {
+				t.Fatal("arc mismatch at node", fr)
+			}
+		}
+	}
+}
+
+func TestSymmetric(t *testing.T) {
+	f := `%%MatrixMarket matrix coordinate integer skew-symmetric
+2 2 1
+2 1 3
+`
+	g, wt, err := mtx.ReadDirected(strings.NewReader(f))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.ArcSize() != 2 || wt[g.LabeledAdjacencyList[0][0].Label] != -3 {
+		t.Fatal("skew-symmetric entry not mirrored")
+	}
+	if _, _, err := mtx.ReadUndirected(strings.NewReader(f)); err == nil {
+		t.Fatal("skew-symmetric accepted as undirected")
+	}
+}
+
+func TestErrors(t *testing.T) {
+	h := "%%MatrixMarket matrix coordinate real general\n"
+	for _, f := range []string{
+		"",
+		"2 2 0\n",
+		"%%MatrixMarket matrix array real general\n2 2\n",
+		"%%MatrixMarket matrix coordinate complex general\n2 2 0\n",
+		"%%MatrixMarket matrix coordinate real hermitian\n2 2 0\n",
+		"%%MatrixMarket matrix coordinate real symmetric\n2 2 1\n1 2 1\n",
+		h + "2 3 0\n",
+		h + "2 2 1\n1 3 1\n",
+		h + "2 2 1\n1 2\n",
+		h + "2 2 1\n1 2 x\n",
+		h + "2 2 2\n1 2 1\n",
+		h + "2 2 1\n1 2 1\n2 1 1\n",
+		h + "4294967297 4294967297 0\n",          // size out of range
+		h + "2147483647 2147483647 2147483647\n", // hostile counts
+	} {
+		if _, _, err := mtx.ReadDirected(strings.NewReader(f)); err == nil {
+			t.Errorf("no error for %q", f)
+		}
+	}
+	// isolated nodes are still allocated to the order of the header
+	g, _, err := mtx.ReadDirected(strings.NewReader(h + "5 5 1\n1 2 1\n"))
+	if err != nil || g.Order() != 5 {
+		t.Error("order", g.Order(), err)
+	}
+	u, _, err := mtx.ReadUndirected(strings.NewReader(
+		"%%MatrixMarket matrix coordinate real symmetric\n5 5 1\n2 1 1\n"))
+	if err != nil || u.Order() != 5 {
+		t.Error("undirected order", u.Order(), err)
+	}
+	// hostile sizes, valid otherwise, must fail without attempting large
+	// allocations.
+	for _, f := range []string{
+		"%%MatrixMarket matrix coordinate pattern general\n2000000000 2000000000 0\n",
+		"%%MatrixMarket matrix coordinate pattern symmetric\n2000000000 2000000000 0\n",
+	} {
+		if _, _, err := mtx.ReadDirected(strings.NewReader(f)); err == nil {
+			t.Errorf("hostile size not detected for %q", f)
+		}
+		if _, _, err := mtx.ReadUndirected(strings.NewReader(f)); err == nil {
+			t.Errorf("hostile size not detected for %q", f)
+		}
+	}
+	defer func(m int) { mtx.MaxOrder = m }(mtx.MaxOrder)
+	mtx.MaxOrder = 10
+	if g, _, err := mtx.ReadDirected(strings.NewReader(h + "10 10 0\n")); err != nil || g.Order() != 10 {
+		t.Error("MaxOrder", g.Order(), err)
+	}
+	if _, _, err := mtx.ReadDirected(strings.NewReader(h + "11 11 0\n")); err == nil {
+		t.Error("MaxOrder exceeded not detected")
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright 2026 Sonia Keys
+// License MIT: https://opensource.org/licenses/MIT
+
+package graph
+
+import (
+	"fmt"
+
+	"github.com/soniakeys/bits"
+)
+
+// Mutable is a directed graph supporting node deletion with stable node IDs.
+//
+// The graph types of this package use node numbers that are slice indexes,
+// so deleting a node would require renumbering other nodes.  Mutable instead
+// marks deleted nodes with tombstones.  Node IDs are never reused, so an ID
+// remains valid for the life of a Mutable.  To run algorithms of this
+// package, call Compact to obtain an AdjacencyList with deleted nodes
+// removed, along with mappings between IDs and node numbers of the
+// AdjacencyList.
+//
+// An undirected graph can be represented by adding and removing reciprocal
+// arc pairs.
+//
+// A zero value Mutable is an empty graph ready to use.
+type Mutable struct {
+	g       AdjacencyList
+	deleted bits.Bits // tombstones
+	nDel    int       // number of tombstones
+}
+
+// NewMutable creates a Mutable from an adjacency list.
+//
+// Node IDs of the Mutable are the node numbers of g.  The Mutable takes
+// ownership of g; g should not be used after calling NewMutable.
+func NewMutable(g AdjacencyList) *Mutable {
+	return &Mutable{g: g, deleted: bits.New(len(g))}
+}
+
+// NumIDs returns the number of node IDs assigned, including deleted nodes.
+//
+// Valid node IDs are in the range 0 to NumIDs()-1.
+func (m *Mutable) NumIDs() int {
+	return len(m.g)
+}
+
+// Order returns the number of nodes, not counting deleted nodes.
+func (m *Mutable) Order() int {
+	return len(m.g) - m.nDel
+}
+
+// Live returns true if n is a valid node ID of a node not deleted.
+func (m *Mutable) Live(n NI) bool {
+	return n >= 0 && int(n) < len(m.g) && m.deleted.Bit(int(n)) == 0
+}
+
+// AddNode adds a node and returns its ID.
+func (m *Mutable) AddNode() NI {
+	n := len(m.g)
+	m.g = append(m.g, nil)
+	if n >= m.deleted.Num {
+		// grow tombstones by doubling
+		d := bits.New(2*n + 1)
+		copy(d.Bits, m.deleted.Bits)
+		m.deleted = d
+	}
+	return NI(n)
+}
+
+// RemoveNode deletes node n.
+//
+// Arcs from n are removed immediately.  Arcs to n from other nodes are
+// ignored by methods of Mutable and are removed by Compact or by calls
+// to Arcs.  An error is returned if n is not a live node.
+func (m *Mutable) RemoveNode(n NI) error {
+	if !m.Live(n) {
+		return fmt.Errorf("node %d not live", n)
+	}
+	m.g[n] = nil
+	m.deleted.SetBit(int(n), 1)
+	m.nDel++
+	return nil
+}
+
+// AddArc adds an arc from node fr to node to.
+//
+// Parallel arcs and loops are allowed.  An error is returned if either node
+// is not live.
+func (m *Mutable) AddArc(fr, to NI) error {
+	switch {
+	case !m.Live(fr):
+		return fmt.Errorf("node %d not live", fr)
+	case !m.Live(to):
+		return fmt.Errorf("node %d not live", to)
+	}
+	m.g[fr] = append(m.g[fr], to)
+	return nil
+}
+
+// RemoveArc removes an arc from node fr to node to.
+//
+// If there are parallel arcs, only one is removed.  RemoveArc returns true
+// if an arc was removed, false if there was no arc from fr to to.
+func (m *Mutable) RemoveArc(fr, to NI) bool {
+	if !m.Live(fr) || !m.Live(to) {
+		return false
+	}
+	a := m.g[fr]
+	for x, n := range a {
+		if n == to {
+			last := len(a) - 1
+			a[x] = a[last]
+			m.g[fr] = a[:last]
+			return true
+		}
+	}
+	return false
+}
+
+// HasArc returns true if there is an arc from node fr to node to.
+func (m *Mutable) HasArc(fr, to NI) bool {
+	if !m.Live(fr) || !m.Live(to) {
+		return false
+	}
+	for _, n := range m.g[fr] {
+		if n == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Arcs returns the arcs from node n, as a list of to nodes.
+//
+// Arcs to deleted nodes are removed from the list.  The returned slice is
+// the internal representation of the arcs from n.  It is valid only until
+// the next modification of the graph and should not be modified.
+func (m *Mutable) Arcs(n NI) []NI {
+	if !m.Live(n) {
+		return nil
+	}
+	a := m.g[n][:0]
+	for _, to := range m.g[n] {
+		if m.deleted.Bit(int(to)) == 0 {
+			a = append(a, to)
+		}
+	}
+	m.g[n] = a
+	return a
+}
+
+// Compact returns an adjacency list of the live nodes and arcs of m.
+//
+// Live nodes are numbered consecutively in order of ID.  Returned slice
+// toNew maps IDs to node numbers of g, with -1 for deleted nodes.  It has
+// length m.NumIDs().  Returned slice toID maps node numbers of g back to
+// IDs.  It has length m.Order().
+//
+// The Mutable itself is not renumbered.  IDs remain valid and the Mutable
+// can continue to be modified.  Arcs to deleted nodes are removed from m.
+func (m *Mutable) Compact() (g AdjacencyList, toNew, toID []NI) {
+	toNew = make([]NI, len(m.g))
+	toID = make([]NI, 0, m.Order())
+	for n := range m.g {
+		if m.deleted.Bit(n) == 1 {
+			toNew[n] = -1
+			continue
+		}
+		toNew[n] = NI(len(toID))
+		toID = append(toID, NI(n))
+	}
+	g = make(AdjacencyList, len(toID))
+	for nn, id := range toID {
+		a := m.Arcs(id)
+		if len(a) == 0 {
+			continue
+		}
+		to := make([]NI, len(a))
+		for x, n := range a {
+			to[x] = toNew[n]
+		}
+		g[nn] = to
+	}
+	return
+}
@@ -0,0 +1,97 @@
+// Copyright 2026 Sonia Keys
+// License MIT: https://opensource.org/licenses/MIT
+
+package graph_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/soniakeys/graph"
+)
+
+func ExampleMutable() {
+	var m graph.Mutable
+	a := m.AddNode()
+	b := m.AddNode()
+	c := m.AddNode()
+	d := m.AddNode()
+	m.AddArc(a, b)
+	m.AddArc(b, c)
+	m.AddArc(c, d)
+	m.AddArc(a, d)
+	fmt.Println(m.Order(), m.NumIDs())
+
+	m.RemoveNode(b)
+	fmt.Println(m.Order(), m.NumIDs(), m.Live(b))
+	fmt.Println(m.AddArc(a, b))
+
+	g, toNew, toID := m.Compact()
+	fmt.Println(g)
+	fmt.Println(toNew, toID)
+
+	// the Mutable can continue to evolve with IDs unchanged
+	e := m.AddNode()
+	m.AddArc(d, e)
+	fmt.Println(e, m.Arcs(d))
+	// Output:
+	// 4 4
+	// 3 4 false
+	// node 1 not live
+	// [[2] [2] []]
+	// [0 -1 1 2] [0 2 3]
+	// 4 [4]
+}
+
+func ExampleMutable_RemoveArc() {
+	m := graph.NewMutable(graph.AdjacencyList{
+		0: {1, 1},
+		1: {0},
+	})
+	fmt.Println(m.RemoveArc(0, 1), m.HasArc(0, 1))
+	fmt.Println(m.RemoveArc(0, 1), m.HasArc(0, 1))
+	fmt.Println(m.RemoveArc(0, 1))
+	// Output:
+	// true true
+	// true false
+	// false
+}
+
+func TestMutableCompact(t *testing.T) {
+	r := rand.New(rand.NewSource(33))
+	g := graph.GnmDirected(100, 500, r)
+	c0, _ := g.AdjacencyList.Copy()
+	m := graph.NewMutable(c0)
+	for i := 0; i < 30; i++ {
+		m.RemoveNode(graph.NI(r.Intn(100)))
+	}
+	c, toNew, toID := m.Compact()
+	if len(c) != m.Order() || len(toID) != len(c) || len(toNew) != 100 {
+		t.Fatal("order mismatch")
+	}
+	// every arc of g between live nodes must be in c, and nothing else
+	ma := 0
+	for fr, to := range g.AdjacencyList {
+		if toNew[fr] < 0 {
+			continue
+		}
+		for _, to := range to {
+			if toNew[to] < 0 {
+				continue
+			}
+			ma++
+			if ok, _ := c.HasArc(toNew[fr], toNew[to]); !ok {
+				t.Fatal("missing arc", fr, to)
+			}
+		}
+	}
+	if c.ArcSize() != ma {
+		t.Fatal("arc size", c.ArcSize(), "expected", ma)
+	}
+	for nn, id := range toID {
+		if toNew[id] != graph.NI(nn) {
+			t.Fatal("mapping mismatch")
+		}
+	}
+}
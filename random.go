@@ -706,3 +706,82 @@ ij:
 	}
 	return
 }
+
+// RandomTree constructs a uniformly random labeled tree of order n.
+//
+// Each of the n^(n-2) labeled trees on n nodes is equally likely.
+// Construction is by decoding a random Prüfer sequence.
+//
+// If Rand r is nil, the rand package default shared source is used.
+//
+// See also PruferUndirected.
+func RandomTree(n int, rr *rand.Rand) Undirected {
+	if n < 2 {
+		return Undirected{make(AdjacencyList, n)}
+	}
+	ri := rand.Intn
+	if rr != nil {
+		ri = rr.Intn
+	}
+	s := make([]NI, n-2)
+	for i := range s {
+		s[i] = NI(ri(n))
+	}
+	g, _ := PruferUndirected(s)
+	return g
+}
+
+// RandomSpanningTree constructs a uniformly random spanning tree of g.
+//
+// Construction is by Wilson's algorithm of loop-erased random walks.  Each
+// spanning tree of the connected component of g containing root is equally
+// likely.  Parallel edges make trees using them proportionally more likely.
+//
+// The tree is returned as a FromList rooted at root.  For nodes of the
+// component containing root, From and Len values are populated.  MaxLen is
+// set but Leaves is left a zero value.  Nodes not reachable from root will
+// have PathEnd values of {From: -1, Len: 0}.
+//
+// If Rand r is nil, the rand package default shared source is used.
+func (g Undirected) RandomSpanningTree(root NI, rr *rand.Rand) FromList {
+	ri := rand.Intn
+	if rr != nil {
+		ri = rr.Intn
+	}
+	a := g.AdjacencyList
+	f := NewFromList(len(a))
+	p := f.Paths
+	for i := range p {
+		p[i].From = -1
+	}
+	var comp []NI
+	g.BreadthFirst(root, NodeVisitor(func(n NI) {
+		comp = append(comp, n)
+	}))
+	p[root].Len = 1
+	f.MaxLen = 1
+	next := make([]NI, len(a))
+	var path []NI
+	for _, n := range comp {
+		// random walk from n until reaching the tree
+		for u := n; p[u].Len == 0; u = next[u] {
+			next[u] = a[u][ri(len(a[u]))]
+		}
+		// follow the walk, which is now loop-erased, adding it to the tree
+		path = path[:0]
+		u := n
+		for ; p[u].Len == 0; u = next[u] {
+			path = append(path, u)
+		}
+		l := p[u].Len
+		for i := len(path) - 1; i >= 0; i-- {
+			u := path[i]
+			l++
+			p[u] = PathEnd{From: next[u], Len: l}
+		}
+		if l > f.MaxLen {
+			f.MaxLen = l
+		}
+	}
+	return f
+}
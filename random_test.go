@@ -319,3 +319,66 @@ func TestChungLu(t *testing.T) {
 		t.Fatal("ChungLu returned non-simple graph")
 	}
 }
+
+func TestRandomTree(t *testing.T) {
+	// each of the 4^2 labeled trees on 4 nodes should be equally likely
+	r := rand.New(rand.NewSource(45))
+	const samples = 16000
+	count := map[string]int{}
+	for i := 0; i < samples; i++ {
+		g := graph.RandomTree(4, r)
+		s, err := g.PruferSequence()
+		if err != nil {
+			t.Fatal(err)
+		}
+		count[fmt.Sprint(s)]++
+	}
+	if len(count) != 16 {
+		t.Fatal("trees generated:", len(count))
+	}
+	for s, c := range count {
+		if c < 800 || c > 1200 {
+			t.Fatal("tree", s, "count", c)
+		}
+	}
+}
+
+func TestRandomSpanningTree(t *testing.T) {
+	// K4 has 16 spanning trees, each should be equally likely
+	var g graph.Undirected
+	for i := graph.NI(0); i < 4; i++ {
+		for j := i + 1; j < 4; j++ {
+			g.AddEdge(i, j)
+		}
+	}
+	g.AddEdge(4, 5) // a separate component
+	r := rand.New(rand.NewSource(45))
+	const samples = 16000
+	count := map[string]int{}
+	for i := 0; i < samples; i++ {
+		f := g.RandomSpanningTree(1, r)
+		if f.Paths[4].Len != 0 || f.Paths[5].Len != 0 {
+			t.Fatal("spanned other component")
+		}
+		f.Paths = f.Paths[:4]
+		tr, _ := f.Undirected(nil)
+		if isTree, allTree := tr.IsTree(1); !isTree || !allTree {
+			t.Fatal("not a spanning tree")
+		}
+		for n, e := range f.Paths {
+			if e.Len != len(f.PathTo(graph.NI(n), nil)) {
+				t.Fatal("Len", n)
+			}
+		}
+		s, _ := tr.PruferSequence()
+		count[fmt.Sprint(s)]++
+	}
+	if len(count) != 16 {
+		t.Fatal("trees generated:", len(count))
+	}
+	for s, c := range count {
+		if c < 800 || c > 1200 {
+			t.Fatal("tree", s, "count", c)
+		}
+	}
+}
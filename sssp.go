@@ -356,14 +356,30 @@ func (p *openHeap) Pop() interface{} {
 //
 // See also NegativeCycle to find a cycle anywhere in the graph, and see
 // HasNegativeCycle for lighter-weight negative cycle detection,
+//
+// Distances of nodes not reached from start are +Inf.  This method is a
+// wrapper for the generic function BellmanFord.
 func (g LabeledDirected) BellmanFord(w WeightFunc, start NI) (f FromList, dist []float64, end NI) {
-	a := g.LabeledAdjacencyList
-	f = NewFromList(len(a))
-	dist = make([]float64, len(a))
+	f, dist, end = BellmanFord(g, w, start)
 	inf := math.Inf(1)
-	for i := range dist {
-		dist[i] = inf
+	for n, p := range f.Paths {
+		if p.Len == 0 {
+			dist[n] = inf
+		}
 	}
+	return
+}
+
+// BellmanFord finds shortest paths from a start node in a weighted directed
+// graph using the Bellman-Ford-Moore algorithm.
+//
+// It is the generic version of LabeledDirected.BellmanFord, accepting any
+// Weight type.  See the method for documentation.  Distances of nodes not
+// reached from start are zero.
+func BellmanFord[W Weight](g LabeledDirected, w func(LI) W, start NI) (f FromList, dist []W, end NI) {
+	a := g.LabeledAdjacencyList
+	f = NewFromList(len(a))
+	dist = make([]W, len(a))
 	rp := f.Paths
 	rp[start] = PathEnd{Len: 1, From: -1}
 	dist[start] = 0
@@ -376,7 +392,7 @@ func (g LabeledDirected) BellmanFord(w WeightFunc, start NI) (f FromList, dist [
 				d2 := d1 + w(nb.Label)
 				to := &rp[nb.To]
 				// TODO improve to break ties
-				if fp.Len > 0 && d2 < dist[nb.To] {
+				if fp.Len > 0 && (to.Len == 0 || d2 < dist[nb.To]) {
 					*to = PathEnd{From: NI(from), Len: fp.Len + 1}
 					dist[nb.To] = d2
 					imp = true
@@ -388,6 +404,9 @@ func (g LabeledDirected) BellmanFord(w WeightFunc, start NI) (f FromList, dist [
 		}
 	}
 	for from, nbs := range a {
+		if rp[from].Len == 0 {
+			continue
+		}
 		d1 := dist[from]
 		for _, nb := range nbs {
 			if d1+w(nb.Label) < dist[nb.To] {
@@ -575,11 +594,22 @@ func (g LabeledDirected) dagPath(start, end NI, w WeightFunc, longest bool) ([]N
 //
 // Paths and path distances are encoded in the returned FromList and dist
 // slice.   The number of nodes reached is returned as nReached.
+//
+// This method is a wrapper for the generic function DAGOptimalPaths.
 func (g LabeledDirected) DAGOptimalPaths(start, end NI, ordering []NI, w WeightFunc, longest bool) (f FromList, dist []float64, nReached int) {
+	return DAGOptimalPaths(g, start, end, ordering, w, longest)
+}
+
+// DAGOptimalPaths finds either longest or shortest distance paths in a
+// directed acyclic graph.
+//
+// It is the generic version of LabeledDirected.DAGOptimalPaths, accepting
+// any Weight type.  See the method for documentation.
+func DAGOptimalPaths[W Weight](g LabeledDirected, start, end NI, ordering []NI, w func(LI) W, longest bool) (f FromList, dist []W, nReached int) {
 	a := g.LabeledAdjacencyList
 	f = NewFromList(len(a))
 	f.Leaves = bits.New(len(a))
-	dist = make([]float64, len(a))
+	dist = make([]W, len(a))
 	if ordering == nil {
 		ordering, _ = g.Topological()
 	}
@@ -588,13 +618,13 @@ func (g LabeledDirected) DAGOptimalPaths(start, end NI, ordering []NI, w WeightF
 	for ordering[o] != start {
 		o++
 	}
-	var fBetter func(cand, ext float64) bool
+	var fBetter func(cand, ext W) bool
 	var iBetter func(cand, ext int) bool
 	if longest {
-		fBetter = func(cand, ext float64) bool { return cand > ext }
+		fBetter = func(cand, ext W) bool { return cand > ext }
 		iBetter = func(cand, ext int) bool { return cand > ext }
 	} else {
-		fBetter = func(cand, ext float64) bool { return cand < ext }
+		fBetter = func(cand, ext W) bool { return cand < ext }
 		iBetter = func(cand, ext int) bool { return cand < ext }
 	}
 	p := f.Paths
@@ -643,13 +673,23 @@ func (g LabeledDirected) DAGOptimalPaths(start, end NI, ordering []NI, w WeightF
 // As usual for Dijkstra's algorithm, arc weights must be non-negative.
 // Graphs may be directed or undirected.  Loops and parallel arcs are
 // allowed.
+//
+// This method is a wrapper for the generic function Dijkstra.
 func (g LabeledAdjacencyList) Dijkstra(start, end NI, w WeightFunc) (f FromList, dist []float64, reached int) {
-	r := make([]tentResult, len(g))
+	return Dijkstra(g, start, end, w)
+}
+
+// Dijkstra finds shortest paths by Dijkstra's algorithm.
+//
+// It is the generic version of LabeledAdjacencyList.Dijkstra, accepting
+// any Weight type.  See the method for documentation.
+func Dijkstra[W Weight](g LabeledAdjacencyList, start, end NI, w func(LI) W) (f FromList, dist []W, reached int) {
+	r := make([]tentResult[W], len(g))
 	for i := range r {
 		r[i].nx = NI(i)
 	}
 	f = NewFromList(len(g))
-	dist = make([]float64, len(g))
+	dist = make([]W, len(g))
 	current := start
 	rp := f.Paths
 	rp[current] = PathEnd{Len: 1, From: -1} // path length at start is 1 node
@@ -657,7 +697,7 @@ func (g LabeledAdjacencyList) Dijkstra(start, end NI, w WeightFunc) (f FromList,
 	cr.dist = 0    // distance at start is 0.
 	cr.done = true // mark start done.  it skips the heap.
 	nDone := 1     // accumulated for a return value
-	var t tent
+	var t tent[W]
 	for current != end {
 		nextLen := rp[current].Len + 1
 		for _, nb := range g[current] {
@@ -695,7 +735,7 @@ func (g LabeledAdjacencyList) Dijkstra(start, end NI, w WeightFunc) (f FromList,
 			return f, dist, nDone // no more reachable nodes. AllPaths normal return
 		}
 		// new current is node with smallest tentative distance
-		cr = heap.Pop(&t).(*tentResult)
+		cr = heap.Pop(&t).(*tentResult[W])
 		cr.done = true
 		nDone++
 		current = cr.nx
@@ -714,30 +754,30 @@ func (g LabeledAdjacencyList) DijkstraPath(start, end NI, w WeightFunc) ([]NI, f
 }
 
 // tent implements container/heap
-func (t tent) Len() int           { return len(t) }
-func (t tent) Less(i, j int) bool { return t[i].dist < t[j].dist }
-func (t tent) Swap(i, j int) {
+func (t tent[W]) Len() int           { return len(t) }
+func (t tent[W]) Less(i, j int) bool { return t[i].dist < t[j].dist }
+func (t tent[W]) Swap(i, j int) {
 	t[i], t[j] = t[j], t[i]
 	t[i].fx = i
 	t[j].fx = j
 }
-func (s *tent) Push(x interface{}) {
-	nd := x.(*tentResult)
+func (s *tent[W]) Push(x interface{}) {
+	nd := x.(*tentResult[W])
 	nd.fx = len(*s)
 	*s = append(*s, nd)
 }
-func (s *tent) Pop() interface{} {
+func (s *tent[W]) Pop() interface{} {
 	t := *s
 	last := len(t) - 1
 	*s = t[:last]
 	return t[last]
 }
 
-type tentResult struct {
-	dist float64 // tentative distance, sum of arc weights
-	nx   NI      // slice index, "node id"
-	fx   int     // heap.Fix index
+type tentResult[W Weight] struct {
+	dist W   // tentative distance, sum of arc weights
+	nx   NI  // slice index, "node id"
+	fx   int // heap.Fix index
 	done bool
 }
 
-type tent []*tentResult
+type tent[W Weight] []*tentResult[W]
@@ -446,6 +446,79 @@ func ExampleLabeledAdjacencyList_Dijkstra_allPaths() {
 	// 5:     [2 5]                   2     2
 }
 
+func ExampleDijkstra() {
+	// exact integer costs, in cents
+	g := graph.LabeledAdjacencyList{
+		0: {{To: 1, Label: 0}, {To: 2, Label: 1}},
+		1: {{To: 2, Label: 2}},
+		2: {},
+	}
+	cost := []int64{1e15 + 1, 2e15 + 3, 1e15 + 1}
+	f, dist, _ := graph.Dijkstra(g, 0, -1, func(l graph.LI) int64 { return cost[l] })
+	fmt.Println(f.PathTo(2, nil), dist[2])
+	// Output:
+	// [0 1 2] 2000000000000002
+}
+
+func TestGenericWeights(t *testing.T) {
+	// integer weights give identical results to float64 weights
+	r := rand.New(rand.NewSource(38))
+	g := graph.LabeledDirected{make(graph.LabeledAdjacencyList, 50)}
+	var wi []int
+	for i := 0; i < 200; i++ {
+		fr, to := r.Intn(50), r.Intn(50)
+		if fr > to {
+			fr, to = to, fr // make it a DAG
+		}
+		if fr == to {
+			continue
+		}
+		g.LabeledAdjacencyList[fr] = append(g.LabeledAdjacencyList[fr],
+			graph.Half{To: graph.NI(to), Label: graph.LI(len(wi))})
+		wi = append(wi, r.Intn(100)-20)
+	}
+	wf := func(l graph.LI) float64 { return float64(wi[l]) }
+	wn := func(l graph.LI) int { return wi[l] }
+	same := func(fd []float64, f1, f2 graph.FromList, id []int) bool {
+		for n, p := range f1.Paths {
+			if p != f2.Paths[n] || p.Len > 0 && fd[n] != float64(id[n]) {
+				return false
+			}
+		}
+		return true
+	}
+	f1, d1, e1 := g.BellmanFord(wf, 0)
+	f2, d2, e2 := graph.BellmanFord(g, wn, 0)
+	if e1 != e2 || !same(d1, f1, f2, d2) {
+		t.Fatal("BellmanFord mismatch")
+	}
+	f1, d1, n1 := g.DAGOptimalPaths(0, -1, nil, wf, true)
+	f2, d2, n2 := graph.DAGOptimalPaths(g, 0, -1, nil, wn, true)
+	if n1 != n2 || !same(d1, f1, f2, d2) {
+		t.Fatal("DAGOptimalPaths mismatch")
+	}
+	for i := range wi {
+		if wi[i] < 0 {
+			wi[i] = -wi[i]
+		}
+	}
+	f1, d1, n1 = g.Dijkstra(0, -1, wf)
+	f2, d2, n2 = graph.Dijkstra(g.LabeledAdjacencyList, 0, -1, wn)
+	if n1 != n2 || !same(d1, f1, f2, d2) {
+		t.Fatal("Dijkstra mismatch")
+	}
+	fw1 := g.FloydWarshall(wf)
+	fw2, path := graph.FloydWarshall(g.LabeledAdjacencyList, wn)
+	for i, fi := range fw1 {
+		for j, d := range fi {
+			if math.IsInf(d, 1) != (path[i].Bit(j) == 0) ||
+				!math.IsInf(d, 1) && d != float64(fw2[i][j]) {
+				t.Fatal("FloydWarshall mismatch")
+			}
+		}
+	}
+}
+
 func TestSSSP(t *testing.T) {
 	r100 := r(100, 200, 62)
 	testSSSP(r100, t)
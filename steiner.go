@@ -0,0 +1,271 @@
+// Copyright 2026 Sonia Keys
+// License MIT: https://opensource.org/licenses/MIT
+
+package graph
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/soniakeys/bits"
+)
+
+// SteinerTree constructs an approximate minimum Steiner tree.
+//
+// A Steiner tree connects a given set of terminal nodes, possibly using
+// other nodes of the graph.  Finding a minimum Steiner tree is NP-hard.
+// SteinerTree uses the algorithm of Kou, Markowsky, and Berman, which finds
+// a tree with cost at most twice the minimum:  The metric closure of the
+// terminals is computed with Dijkstra's algorithm from each terminal, a
+// minimum spanning tree of the closure is found with Kruskal's algorithm,
+// its edges are expanded to shortest paths of g, a minimum spanning tree of
+// the resulting subgraph is found, and finally non-terminal leaves are
+// pruned.
+//
+// Arc weights are obtained from labels by w and must be non-negative.
+//
+// The tree is returned as a LabeledUndirected t with the order of g.  Edges
+// of t are edges of g, with the same labels.  Nodes of g not in the tree are
+// isolated in t.  Also returned is the total weight of the tree.  If the
+// terminals are not all connected in g, an error is returned.
+//
+// See also SteinerTreeMehlhorn, which is faster for graphs with many
+// terminals.
+func (g LabeledUndirected) SteinerTree(terminals []NI, w WeightFunc) (t LabeledUndirected, cost float64, err error) {
+	a := g.LabeledAdjacencyList
+	if len(terminals) == 0 {
+		return LabeledUndirected{make(LabeledAdjacencyList, len(a))}, 0, nil
+	}
+	// metric closure of terminals
+	fs := make([]FromList, len(terminals))
+	var cw []float64 // closure edge weights
+	var ce []LabeledEdge
+	for i, ti := range terminals {
+		f, dist, _ := a.Dijkstra(ti, -1, w)
+		fs[i] = f
+		for j, tj := range terminals[:i] {
+			if f.Paths[tj].Len == 0 {
+				return t, 0, fmt.Errorf("terminal %d not reachable from terminal %d", tj, ti)
+			}
+			ce = append(ce, LabeledEdge{Edge{NI(i), NI(j)}, LI(len(cw))})
+			cw = append(cw, dist[tj])
+		}
+	}
+	mst, _ := Kruskal(len(terminals), ce, func(l LI) float64 { return cw[l] })
+	// expand closure edges to paths
+	var sub []LabeledEdge
+	for i, to := range mst.LabeledAdjacencyList {
+		for _, to := range to {
+			if to.To < NI(i) {
+				sub = g.appendPath(sub, fs[i], terminals[to.To], w)
+			}
+		}
+	}
+	return g.steinerFinish(terminals, sub, w)
+}
+
+// SteinerTreeMehlhorn constructs an approximate minimum Steiner tree by
+// Mehlhorn's variant of the algorithm of Kou, Markowsky, and Berman.
+//
+// Rather than computing the full metric closure of the terminals with a
+// Dijkstra search from each terminal, a single Dijkstra search from all
+// terminals at once partitions nodes by nearest terminal.  Edges of g
+// crossing between partitions give a graph on the terminals that serves in
+// place of the metric closure.  The approximation guarantee of cost at most
+// twice the minimum is unchanged.
+//
+// Arguments and return values are as for SteinerTree.
+func (g LabeledUndirected) SteinerTreeMehlhorn(terminals []NI, w WeightFunc) (t LabeledUndirected, cost float64, err error) {
+	a := g.LabeledAdjacencyList
+	if len(terminals) == 0 {
+		return LabeledUndirected{make(LabeledAdjacencyList, len(a))}, 0, nil
+	}
+	terminals = uniqueNodes(terminals, len(a))
+	// search from a virtual source with arcs to all terminals.  the
+	// virtual arcs have label -1 and weight 0.
+	src := NI(len(a))
+	v := make(LabeledAdjacencyList, len(a)+1)
+	copy(v, a)
+	for _, tn := range terminals {
+		v[src] = append(v[src], Half{tn, -1})
+	}
+	vw := func(l LI) float64 {
+		if l < 0 {
+			return 0
+		}
+		return w(l)
+	}
+	f, dist, _ := v.Dijkstra(src, -1, vw)
+	p := f.Paths
+	// nearest terminal of each node
+	tx := make([]int, len(a)+1) // index into terminals, or -1
+	for i := range tx {
+		tx[i] = -2
+	}
+	for i, tn := range terminals {
+		tx[tn] = i
+	}
+	var base func(NI) int
+	base = func(n NI) int {
+		if tx[n] == -2 {
+			if p[n].Len == 0 {
+				tx[n] = -1
+			} else {
+				tx[n] = base(p[n].From)
+			}
+		}
+		return tx[n]
+	}
+	// for each pair of terminals, the lightest crossing edge
+	type cross struct {
+		e LabeledEdge
+		d float64
+	}
+	best := map[Edge]cross{}
+	for n1, to := range a {
+		b1 := base(NI(n1))
+		if b1 < 0 {
+			continue
+		}
+		for _, to := range to {
+			b2 := base(to.To)
+			if b2 <= b1 {
+				continue // unreached, same partition, or seen from the other end
+			}
+			d := dist[n1] + w(to.Label) + dist[to.To]
+			k := Edge{NI(b1), NI(b2)}
+			if c, ok := best[k]; !ok || d < c.d {
+				best[k] = cross{LabeledEdge{Edge{NI(n1), to.To}, to.Label}, d}
+			}
+		}
+	}
+	keys := make([]Edge, 0, len(best))
+	for k := range best {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].N1 < keys[j].N1 ||
+			keys[i].N1 == keys[j].N1 && keys[i].N2 < keys[j].N2
+	})
+	var ce []LabeledEdge
+	var cw []float64
+	var cross0 []LabeledEdge // crossing edge of g for each closure edge
+	for _, k := range keys {
+		c := best[k]
+		ce = append(ce, LabeledEdge{k, LI(len(cw))})
+		cw = append(cw, c.d)
+		cross0 = append(cross0, c.e)
+	}
+	mst, _ := Kruskal(len(terminals), ce, func(l LI) float64 { return cw[l] })
+	if mst.Size() != len(terminals)-1 {
+		// find a terminal not connected to the first
+		c := NewConnectivity(len(terminals))
+		for i, to := range mst.LabeledAdjacencyList {
+			for _, to := range to {
+				c.AddEdge(Edge{NI(i), to.To})
+			}
+		}
+		for i := range terminals {
+			if !c.Connected(0, NI(i)) {
+				return t, 0, fmt.Errorf("terminal %d not reachable from terminal %d", terminals[i], terminals[0])
+			}
+		}
+	}
+	// expand to paths from the crossing edge to the two terminals
+	var sub []LabeledEdge
+	for i, to := range mst.LabeledAdjacencyList {
+		for _, to := range to {
+			if to.To >= NI(i) {
+				continue
+			}
+			e := cross0[to.Label]
+			sub = append(sub, e)
+			sub = g.appendPath(sub, f, e.N1, w)
+			sub = g.appendPath(sub, f, e.N2, w)
+		}
+	}
+	return g.steinerFinish(terminals, sub, w)
+}
+
+// appendPath appends to sub the edges of the path of f to node end.
+//
+// Where the path passes between nodes with parallel arcs, the lightest is
+// used.  Path arcs from nodes not in g, such as a virtual source, are
+// skipped.
+func (g LabeledUndirected) appendPath(sub []LabeledEdge, f FromList, end NI, w WeightFunc) []LabeledEdge {
+	a := g.LabeledAdjacencyList
+	for n := end; ; {
+		fr := f.Paths[n].From
+		if fr < 0 || int(fr) >= len(a) {
+			return sub
+		}
+		l := LI(-1)
+		for _, to := range a[fr] {
+			if to.To == n && (l < 0 || w(to.Label) < w(l)) {
+				l = to.Label
+			}
+		}
+		sub = append(sub, LabeledEdge{Edge{fr, n}, l})
+		n = fr
+	}
+}
+
+// steinerFinish finds a minimum spanning forest of the edges of sub and
+// prunes non-terminal leaves.
+func (g LabeledUndirected) steinerFinish(terminals []NI, sub []LabeledEdge, w WeightFunc) (t LabeledUndirected, cost float64, err error) {
+	n := g.Order()
+	f, _ := Kruskal(n, sub, w)
+	term := bits.New(n)
+	for _, tn := range terminals {
+		term.SetBit(int(tn), 1)
+	}
+	ft := f.LabeledAdjacencyList
+	deg := make([]int, n)
+	var leaves []NI
+	for i, to := range ft {
+		deg[i] = len(to)
+		if deg[i] == 1 && term.Bit(i) == 0 {
+			leaves = append(leaves, NI(i))
+		}
+	}
+	gone := bits.New(n)
+	for len(leaves) > 0 {
+		l := leaves[len(leaves)-1]
+		leaves = leaves[:len(leaves)-1]
+		gone.SetBit(int(l), 1)
+		for _, to := range ft[l] {
+			if gone.Bit(int(to.To)) == 1 {
+				continue
+			}
+			if deg[to.To]--; deg[to.To] == 1 && term.Bit(int(to.To)) == 0 {
+				leaves = append(leaves, to.To)
+			}
+		}
+	}
+	t.LabeledAdjacencyList = make(LabeledAdjacencyList, n)
+	for i, to := range ft {
+		if gone.Bit(i) == 1 {
+			continue
+		}
+		for _, to := range to {
+			if to.To > NI(i) && gone.Bit(int(to.To)) == 0 {
+				t.AddEdge(Edge{NI(i), to.To}, to.Label)
+				cost += w(to.Label)
+			}
+		}
+	}
+	return
+}
+
+// uniqueNodes returns nodes with duplicates removed, preserving order.
+func uniqueNodes(nodes []NI, order int) []NI {
+	seen := bits.New(order)
+	u := make([]NI, 0, len(nodes))
+	for _, n := range nodes {
+		if seen.Bit(int(n)) == 0 {
+			seen.SetBit(int(n), 1)
+			u = append(u, n)
+		}
+	}
+	return u
+}
@@ -0,0 +1,190 @@
+// Copyright 2026 Sonia Keys
+// License MIT: https://opensource.org/licenses/MIT
+
+package graph_test
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/soniakeys/graph"
+)
+
+func ExampleLabeledUndirected_SteinerTree() {
+	// Terminals 0, 2, and 4.  Edge weights are labels.
+	//
+	//   0 --4-- 1 --4-- 2
+	//    \             /
+	//     2           2
+	//      \         /
+	//       `-- 5 --'
+	//           |
+	//           2
+	//           |
+	//   3 --1-- 4
+	var g graph.LabeledUndirected
+	g.AddEdge(graph.Edge{0, 1}, 4)
+	g.AddEdge(graph.Edge{1, 2}, 4)
+	g.AddEdge(graph.Edge{0, 5}, 2)
+	g.AddEdge(graph.Edge{2, 5}, 2)
+	g.AddEdge(graph.Edge{4, 5}, 2)
+	g.AddEdge(graph.Edge{3, 4}, 1)
+	w := func(l graph.LI) float64 { return float64(l) }
+	t, cost, err := g.SteinerTree([]graph.NI{0, 2, 4}, w)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println("cost", cost)
+	for fr, to := range t.LabeledAdjacencyList {
+		fmt.Println(fr, to)
+	}
+	// Output:
+	// cost 6
+	// 0 [{5 2}]
+	// 1 []
+	// 2 [{5 2}]
+	// 3 []
+	// 4 [{5 2}]
+	// 5 [{0 2} {2 2} {4 2}]
+}
+
+func ExampleLabeledUndirected_SteinerTreeMehlhorn() {
+	var g graph.LabeledUndirected
+	g.AddEdge(graph.Edge{0, 1}, 4)
+	g.AddEdge(graph.Edge{1, 2}, 4)
+	g.AddEdge(graph.Edge{0, 5}, 2)
+	g.AddEdge(graph.Edge{2, 5}, 2)
+	g.AddEdge(graph.Edge{4, 5}, 2)
+	g.AddEdge(graph.Edge{3, 4}, 1)
+	w := func(l graph.LI) float64 { return float64(l) }
+	_, cost, err := g.SteinerTreeMehlhorn([]graph.NI{0, 2, 4}, w)
+	fmt.Println(cost, err)
+	g.LabeledAdjacencyList = append(g.LabeledAdjacencyList, nil) // node 6
+	_, _, err = g.SteinerTreeMehlhorn([]graph.NI{0, 6}, w)
+	fmt.Println(err)
+	// Output:
+	// 6 <nil>
+	// terminal 6 not reachable from terminal 0
+}
+
+// steinerOpt finds a minimum Steiner tree cost by trying minimum spanning
+// trees over terminals and every subset of other nodes.
+func steinerOpt(g graph.LabeledUndirected, terminals []graph.NI, w graph.WeightFunc) float64 {
+	n := g.Order()
+	isTerm := make([]bool, n)
+	for _, t := range terminals {
+		isTerm[t] = true
+	}
+	var others []int
+	for i := 0; i < n; i++ {
+		if !isTerm[i] {
+			others = append(others, i)
+		}
+	}
+	best := math.Inf(1)
+	for s := 0; s < 1<<uint(len(others)); s++ {
+		in := append([]bool{}, isTerm...)
+		k := len(terminals)
+		for j, o := range others {
+			if s&(1<<uint(j)) != 0 {
+				in[o] = true
+				k++
+			}
+		}
+		var edges []graph.LabeledEdge
+		g.Edges(func(e graph.LabeledEdge) {
+			if in[e.N1] && in[e.N2] {
+				edges = append(edges, e)
+			}
+		})
+		f, c := graph.Kruskal(n, edges, w)
+		if f.Size() == k-1 && c < best {
+			best = c
+		}
+	}
+	return best
+}
+
+func TestSteiner(t *testing.T) {
+	r := rand.New(rand.NewSource(50))
+	w := func(l graph.LI) float64 { return float64(l) }
+	for tc := 0; tc < 200; tc++ {
+		n := 2 + r.Intn(9)
+		var g graph.LabeledUndirected
+		g.LabeledAdjacencyList = make(graph.LabeledAdjacencyList, n)
+		for i := 0; i < n*3/2; i++ {
+			g.AddEdge(graph.Edge{graph.NI(r.Intn(n)), graph.NI(r.Intn(n))},
+				graph.LI(1+r.Intn(20)))
+		}
+		p := r.Perm(n)
+		terminals := make([]graph.NI, 1+r.Intn(n))
+		for i := range terminals {
+			terminals[i] = graph.NI(p[i])
+		}
+		opt := steinerOpt(g, terminals, w)
+		for _, m := range []struct {
+			name string
+			f    func([]graph.NI, graph.WeightFunc) (graph.LabeledUndirected, float64, error)
+		}{
+			{"KMB", g.SteinerTree},
+			{"Mehlhorn", g.SteinerTreeMehlhorn},
+		} {
+			st, cost, err := m.f(terminals, w)
+			if math.IsInf(opt, 1) {
+				if err == nil {
+					t.Fatal(m.name, "expected error", tc)
+				}
+				continue
+			}
+			if err != nil {
+				t.Fatal(m.name, err, tc)
+			}
+			if cost < opt || cost > 2*opt {
+				t.Fatal(m.name, "cost", cost, "optimum", opt, tc)
+			}
+			// edges of g, summing to cost
+			s := 0.
+			edges := 0
+			st.Edges(func(e graph.LabeledEdge) {
+				if has, _, _ := g.HasEdgeLabel(e.N1, e.N2, e.LI); !has {
+					t.Fatal(m.name, "edge not in g", e, tc)
+				}
+				s += w(e.LI)
+				edges++
+			})
+			if s != cost {
+				t.Fatal(m.name, "sum", s, cost, tc)
+			}
+			// a single tree containing all terminals, leaves all terminals
+			bf := graph.NewFromList(n)
+			st.LabeledAdjacencyList.BreadthFirst(terminals[0], graph.From(&bf))
+			nodes := 0
+			for i, pe := range bf.Paths {
+				if pe.Len > 0 {
+					nodes++
+				}
+				if len(st.LabeledAdjacencyList[i]) > 0 && pe.Len == 0 {
+					t.Fatal(m.name, "not connected", i, tc)
+				}
+			}
+			if edges != nodes-1 {
+				t.Fatal(m.name, "not a tree", tc)
+			}
+			isTerm := make([]bool, n)
+			for _, tn := range terminals {
+				if bf.Paths[tn].Len == 0 {
+					t.Fatal(m.name, "terminal missing", tn, tc)
+				}
+				isTerm[tn] = true
+			}
+			for i, to := range st.LabeledAdjacencyList {
+				if len(to) == 1 && !isTerm[i] {
+					t.Fatal(m.name, "non-terminal leaf", i, tc)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,410 @@
+// Copyright 2026 Sonia Keys
+// License MIT: https://opensource.org/licenses/MIT
+
+package graph
+
+// tree.go -- structural algorithms on trees represented as FromLists.
+//
+// Methods here rely only on the From members of f.Paths.  Other members of
+// the FromList do not need to be valid.  The FromList cannot be cyclic.
+
+import (
+	"fmt"
+
+	"github.com/soniakeys/bits"
+)
+
+// postorder returns nodes of f in postorder.
+//
+// Trees of a forest are ordered by root node number, and children of a node
+// by node number.
+func (f FromList) postorder() (order []NI, ch AdjacencyList) {
+	t, _ := f.Transpose(nil)
+	ch = t.AdjacencyList
+	order = make([]NI, 0, len(ch))
+	type frame struct {
+		n NI
+		x int // index of next child
+	}
+	var stack []frame
+	for r, e := range f.Paths {
+		if e.From >= 0 {
+			continue
+		}
+		stack = append(stack[:0], frame{NI(r), 0})
+		for len(stack) > 0 {
+			top := &stack[len(stack)-1]
+			if to := ch[top.n]; top.x < len(to) {
+				top.x++
+				stack = append(stack, frame{to[top.x-1], 0})
+				continue
+			}
+			order = append(order, top.n)
+			stack = stack[:len(stack)-1]
+		}
+	}
+	return
+}
+
+// Postorder traverses a FromList in postorder.
+//
+// Nodes are visited in order such that for any node n with from node fr,
+// n is visited before fr.  Where f represents a forest, trees are traversed
+// one after another in order of root node number.  Within a tree, children
+// of a node are visited in order of node number.
+//
+// Traversal continues while visitor function v returns true.  It terminates
+// if v returns false.  Postorder returns true if it completes without v
+// returning false.  Postorder returns false if traversal is terminated by v
+// returning false.
+//
+// Only the From members of f.Paths are used.  FromList f cannot be cyclic.
+//
+// See also Preorder.
+func (f FromList) Postorder(v func(NI) bool) bool {
+	order, _ := f.postorder()
+	for _, n := range order {
+		if !v(n) {
+			return false
+		}
+	}
+	return true
+}
+
+// SubtreeSizes returns the number of nodes in the subtree rooted at each
+// node.
+//
+// The size of a leaf is 1.  The size of a root is the number of nodes in its
+// tree.
+func (f FromList) SubtreeSizes() []int {
+	p := f.Paths
+	order, _ := f.postorder()
+	s := make([]int, len(p))
+	for _, n := range order {
+		s[n]++
+		if fr := p[n].From; fr >= 0 {
+			s[fr] += s[n]
+		}
+	}
+	return s
+}
+
+// Heights returns the height of each node.
+//
+// The height of a node is the number of arcs in the longest path from the
+// node down to a leaf of its subtree.  The height of a leaf is 0.
+//
+// See also HeightsLabeled.
+func (f FromList) Heights() []int {
+	hf := f.HeightsLabeled(nil, nil)
+	h := make([]int, len(hf))
+	for n, d := range hf {
+		h[n] = int(d)
+	}
+	return h
+}
+
+// HeightsLabeled returns the weighted height of each node.
+//
+// The weighted height of a node is the greatest distance from the node down
+// to a leaf of its subtree.  Labels[n] must be the label of the arc from
+// f.Paths[n].From to n, as with TransposeLabeled, and arc weights are
+// obtained from labels by w.  Labels and w can be nil, in which case every
+// arc has weight 1.
+func (f FromList) HeightsLabeled(labels []LI, w WeightFunc) []float64 {
+	h, _, _ := f.heights(labels, w)
+	return h
+}
+
+// heights returns heights, leaves at those heights, and the postordering.
+func (f FromList) heights(labels []LI, w WeightFunc) (h []float64, deep []NI, order []NI) {
+	p := f.Paths
+	order, _ = f.postorder()
+	h = make([]float64, len(p))
+	deep = make([]NI, len(p))
+	for n := range deep {
+		deep[n] = NI(n)
+	}
+	for _, n := range order {
+		fr := p[n].From
+		if fr < 0 {
+			continue
+		}
+		if d := h[n] + arcWeight(labels, w, n); d > h[fr] {
+			h[fr] = d
+			deep[fr] = deep[n]
+		}
+	}
+	return
+}
+
+// arcWeight returns the weight of the arc to n, or 1 if labels is nil.
+func arcWeight(labels []LI, w WeightFunc, n NI) float64 {
+	if labels == nil {
+		return 1
+	}
+	return w(labels[n])
+}
+
+// Diameter finds a longest path in the tree or forest.
+//
+// The length of a path is the number of arcs in it, ignoring arc
+// direction.  Returned are endpoints a and b of a longest path, the length
+// d of the path, and the path itself as a list of nodes from a to b.
+// If f represents a forest, the path is a longest path over all trees.
+// If f is empty, Diameter returns a = b = -1.
+//
+// See also DiameterLabeled.
+func (f FromList) Diameter() (a, b NI, d int, path []NI) {
+	a, b, df, path := f.DiameterLabeled(nil, nil)
+	return a, b, int(df), path
+}
+
+// DiameterLabeled finds a path of greatest weighted distance in the tree or
+// forest.
+//
+// Arguments labels and w are as for HeightsLabeled.  Arc weights must be
+// non-negative.  Return values are as for Diameter, with d the weighted
+// distance of the path.
+func (f FromList) DiameterLabeled(labels []LI, w WeightFunc) (a, b NI, d float64, path []NI) {
+	p := f.Paths
+	if len(p) == 0 {
+		return -1, -1, 0, nil
+	}
+	_, ch := f.postorder()
+	h, deep, order := f.heights(labels, w)
+	d = -1
+	var c NI // node where the path turns
+	for _, n := range order {
+		// heights and leaves through the two highest children
+		h1, h2 := 0., 0.
+		e1, e2 := n, n
+		for _, to := range ch[n] {
+			t := h[to] + arcWeight(labels, w, to)
+			switch {
+			case t > h1:
+				h2, e2 = h1, e1
+				h1, e1 = t, deep[to]
+			case t > h2:
+				h2, e2 = t, deep[to]
+			}
+		}
+		if h1+h2 > d {
+			d, a, b, c = h1+h2, e1, e2, n
+		}
+	}
+	for n := a; n != c; n = p[n].From {
+		path = append(path, n)
+	}
+	path = append(path, c)
+	x := len(path)
+	for n := b; n != c; n = p[n].From {
+		path = append(path, n)
+	}
+	for i, j := x, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return
+}
+
+// Center returns the center of the tree.
+//
+// The center is the set of nodes of minimum eccentricity, the maximum number
+// of arcs in a path from the node to any other node of the tree, ignoring
+// arc direction.  The center consists of either one or two nodes.  The
+// center lies at the midpoint of any diameter.
+//
+// FromList f should represent a single tree.  If it represents a forest,
+// the center of the tree with the longest diameter is returned.
+//
+// See also CenterLabeled.
+func (f FromList) Center() []NI {
+	_, _, d, path := f.Diameter()
+	if path == nil {
+		return nil
+	}
+	if d%2 == 0 {
+		return []NI{path[d/2]}
+	}
+	return []NI{path[d/2], path[d/2+1]}
+}
+
+// CenterLabeled returns a node of minimum weighted eccentricity.
+//
+// Arguments labels and w are as for HeightsLabeled.  Arc weights must be
+// non-negative.  Where multiple nodes have the minimum eccentricity, one is
+// returned.  Also returned is the eccentricity of the center node.
+//
+// FromList f should represent a single tree.  If it represents a forest,
+// the center of the tree with the greatest weighted diameter is returned.
+// If f is empty, CenterLabeled returns -1.
+func (f FromList) CenterLabeled(labels []LI, w WeightFunc) (c NI, ecc float64) {
+	_, _, d, path := f.DiameterLabeled(labels, w)
+	if path == nil {
+		return -1, 0
+	}
+	p := f.Paths
+	c, ecc = path[0], d
+	da := 0. // distance from path[0]
+	for i := 1; i < len(path); i++ {
+		n, prev := path[i], path[i-1]
+		if p[n].From == prev {
+			da += arcWeight(labels, w, n)
+		} else {
+			da += arcWeight(labels, w, prev)
+		}
+		e := da
+		if d-da > e {
+			e = d - da
+		}
+		if e < ecc {
+			c, ecc = n, e
+		}
+	}
+	return
+}
+
+// Centroid returns the centroid of the tree.
+//
+// A centroid is a node whose removal leaves no component with more than half
+// the nodes of the tree.  A tree has either one or two centroids.
+//
+// FromList f must represent a single tree.
+func (f FromList) Centroid() (c []NI) {
+	p := f.Paths
+	s := f.SubtreeSizes()
+	// largest component below each node
+	below := make([]int, len(p))
+	for n, e := range p {
+		if e.From >= 0 && s[n] > below[e.From] {
+			below[e.From] = s[n]
+		}
+	}
+	for n := range p {
+		max := len(p) - s[n] // component containing the from node
+		if below[n] > max {
+			max = below[n]
+		}
+		if 2*max <= len(p) {
+			c = append(c, NI(n))
+		}
+	}
+	return
+}
+
+// PruferSequence returns the Prüfer sequence of the tree.
+//
+// The tree is taken as unrooted, ignoring arc directions.  FromList f must
+// represent a single tree of at least two nodes.  Otherwise an error is
+// returned.
+//
+// See also Undirected.PruferSequence and PruferFromList.
+func (f FromList) PruferSequence() ([]NI, error) {
+	var roots bits.Bits
+	g, nRoots := f.Undirected(&roots)
+	if nRoots != 1 {
+		return nil, fmt.Errorf("from list has %d roots, not a single tree", nRoots)
+	}
+	return g.PruferSequence()
+}
+
+// PruferSequence returns the Prüfer sequence of tree g.
+//
+// The Prüfer sequence of a tree of order n is a sequence of n-2 node
+// numbers which uniquely encodes the tree.  Graph g must be connected as a
+// tree of at least two nodes.  Otherwise an error is returned.
+//
+// See also PruferUndirected.
+func (g Undirected) PruferSequence() ([]NI, error) {
+	n := g.Order()
+	if n < 2 {
+		return nil, fmt.Errorf("order %d, tree must have at least 2 nodes", n)
+	}
+	f, cycle := g.FromList(NI(n - 1))
+	if cycle >= 0 {
+		return nil, fmt.Errorf("cycle at node %d, not a tree", cycle)
+	}
+	deg := make([]int, n)
+	for i, e := range f.Paths {
+		if e.Len == 0 {
+			return nil, fmt.Errorf("node %d not connected", i)
+		}
+		deg[i] = len(g.AdjacencyList[i])
+	}
+	s := make([]NI, n-2)
+	ptr := 0
+	for deg[ptr] != 1 {
+		ptr++
+	}
+	leaf := NI(ptr)
+	for i := range s {
+		next := f.Paths[leaf].From
+		s[i] = next
+		if deg[next]--; deg[next] == 1 && next < NI(ptr) {
+			leaf = next
+			continue
+		}
+		for ptr++; deg[ptr] != 1; ptr++ {
+		}
+		leaf = NI(ptr)
+	}
+	return s, nil
+}
+
+// PruferFromList decodes a Prüfer sequence, returning a tree rooted at the
+// highest numbered node.
+//
+// The tree has order len(s)+2.  All members of the returned FromList are
+// populated.  An error is returned if a value of s is out of range.
+//
+// See also FromList.PruferSequence.
+func PruferFromList(s []NI) (FromList, error) {
+	n := len(s) + 2
+	deg := make([]int, n)
+	for i := range deg {
+		deg[i] = 1
+	}
+	for _, v := range s {
+		if v < 0 || int(v) >= n {
+			return FromList{}, fmt.Errorf("value %d out of range", v)
+		}
+		deg[v]++
+	}
+	f := NewFromList(n)
+	p := f.Paths
+	p[n-1].From = -1
+	ptr := 0
+	for deg[ptr] != 1 {
+		ptr++
+	}
+	leaf := NI(ptr)
+	for _, v := range s {
+		p[leaf].From = v
+		if deg[v]--; deg[v] == 1 && v < NI(ptr) {
+			leaf = v
+			continue
+		}
+		for ptr++; deg[ptr] != 1; ptr++ {
+		}
+		leaf = NI(ptr)
+	}
+	p[leaf].From = NI(n - 1)
+	f.RecalcLeaves()
+	f.RecalcLen()
+	return f, nil
+}
+
+// PruferUndirected decodes a Prüfer sequence, returning an undirected tree.
+//
+// The tree has order len(s)+2.  An error is returned if a value of s is out
+// of range.
+//
+// See also Undirected.PruferSequence.
+func PruferUndirected(s []NI) (Undirected, error) {
+	f, err := PruferFromList(s)
+	if err != nil {
+		return Undirected{}, err
+	}
+	g, _ := f.Undirected(nil)
+	return g, nil
+}
@@ -0,0 +1,256 @@
+// Copyright 2026 Sonia Keys
+// License MIT: https://opensource.org/licenses/MIT
+
+package graph_test
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/soniakeys/graph"
+)
+
+//	   0
+//	  / \
+//	 1   2
+//	/ \   \
+//
+// 3   4   5
+//
+//	|
+//	6
+var treeEx = graph.FromList{Paths: []graph.PathEnd{
+	{From: -1}, {From: 0}, {From: 0}, {From: 1}, {From: 1}, {From: 2}, {From: 5},
+}}
+
+func ExampleFromList_Postorder() {
+	treeEx.Postorder(func(n graph.NI) bool {
+		fmt.Print(n, " ")
+		return true
+	})
+	fmt.Println()
+	// Output:
+	// 3 4 1 6 5 2 0
+}
+
+func ExampleFromList_SubtreeSizes() {
+	fmt.Println(treeEx.SubtreeSizes())
+	// Output:
+	// [7 3 3 1 1 2 1]
+}
+
+func ExampleFromList_Heights() {
+	fmt.Println(treeEx.Heights())
+	// Output:
+	// [3 1 2 0 0 1 0]
+}
+
+func ExampleFromList_HeightsLabeled() {
+	// weight of arc to node n is n
+	labels := []graph.LI{0, 1, 2, 3, 4, 5, 6}
+	w := func(l graph.LI) float64 { return float64(l) }
+	fmt.Println(treeEx.HeightsLabeled(labels, w))
+	// Output:
+	// [13 4 11 0 0 6 0]
+}
+
+func ExampleFromList_Diameter() {
+	fmt.Println(treeEx.Diameter())
+	// Output:
+	// 6 3 5 [6 5 2 0 1 3]
+}
+
+func ExampleFromList_DiameterLabeled() {
+	labels := []graph.LI{0, 1, 2, 3, 4, 5, 6}
+	w := func(l graph.LI) float64 { return float64(l) }
+	fmt.Println(treeEx.DiameterLabeled(labels, w))
+	// Output:
+	// 6 4 18 [6 5 2 0 1 4]
+}
+
+func ExampleFromList_Center() {
+	fmt.Println(treeEx.Center())
+	// Output:
+	// [2 0]
+}
+
+func ExampleFromList_CenterLabeled() {
+	labels := []graph.LI{0, 1, 2, 3, 4, 5, 6}
+	w := func(l graph.LI) float64 { return float64(l) }
+	fmt.Println(treeEx.CenterLabeled(labels, w))
+	// Output:
+	// 2 11
+}
+
+func ExampleFromList_Centroid() {
+	fmt.Println(treeEx.Centroid())
+	// Output:
+	// [0]
+}
+
+func TestTreeAlgorithms(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	for tc := 0; tc < 50; tc++ {
+		n := 1 + r.Intn(60)
+		f := graph.NewFromList(n)
+		f.Paths[0].From = -1
+		for i := 1; i < n; i++ {
+			f.Paths[i].From = graph.NI(r.Intn(i))
+		}
+		// brute force eccentricities by breadth first search
+		g, _ := f.Undirected(nil)
+		ecc := make([]int, n)
+		diam := 0
+		for s := range ecc {
+			dist := make([]int, n)
+			for i := range dist {
+				dist[i] = -1
+			}
+			dist[s] = 0
+			q := []graph.NI{graph.NI(s)}
+			for len(q) > 0 {
+				v := q[0]
+				q = q[1:]
+				if dist[v] > ecc[s] {
+					ecc[s] = dist[v]
+				}
+				for _, to := range g.AdjacencyList[v] {
+					if dist[to] < 0 {
+						dist[to] = dist[v] + 1
+						q = append(q, to)
+					}
+				}
+			}
+			if ecc[s] > diam {
+				diam = ecc[s]
+			}
+		}
+		a, b, d, path := f.Diameter()
+		if d != diam || len(path) != d+1 || path[0] != a || path[d] != b {
+			t.Fatal("Diameter", d, diam, path)
+		}
+		for i := 1; i < len(path); i++ {
+			if has, _, _ := g.HasEdge(path[i-1], path[i]); !has {
+				t.Fatal("Diameter path", path)
+			}
+		}
+		minEcc := diam
+		for _, e := range ecc {
+			if e < minEcc {
+				minEcc = e
+			}
+		}
+		c := f.Center()
+		nc := 0
+		for _, e := range ecc {
+			if e == minEcc {
+				nc++
+			}
+		}
+		if len(c) != nc {
+			t.Fatal("Center", c)
+		}
+		for _, v := range c {
+			if ecc[v] != minEcc {
+				t.Fatal("Center", c)
+			}
+		}
+		// unit weights give unweighted results
+		labels := make([]graph.LI, n)
+		one := func(graph.LI) float64 { return 1 }
+		if cl, e := f.CenterLabeled(labels, one); int(e) != minEcc || ecc[cl] != minEcc {
+			t.Fatal("CenterLabeled", cl, e)
+		}
+		// a centroid's removal leaves components of at most n/2 nodes
+		for _, v := range f.Centroid() {
+			for _, to := range g.AdjacencyList[v] {
+				// count nodes reachable from to without passing v
+				vis := map[graph.NI]bool{v: true, to: true}
+				q := []graph.NI{to}
+				for len(q) > 0 {
+					u := q[0]
+					q = q[1:]
+					for _, x := range g.AdjacencyList[u] {
+						if !vis[x] {
+							vis[x] = true
+							q = append(q, x)
+						}
+					}
+				}
+				if 2*(len(vis)-1) > n {
+					t.Fatal("Centroid", v)
+				}
+			}
+		}
+	}
+}
+
+func ExampleUndirected_PruferSequence() {
+	//   0   1
+	//    \ /
+	//     3---4---5
+	//     |
+	//     2
+	var g graph.Undirected
+	g.AddEdge(0, 3)
+	g.AddEdge(1, 3)
+	g.AddEdge(2, 3)
+	g.AddEdge(3, 4)
+	g.AddEdge(4, 5)
+	fmt.Println(g.PruferSequence())
+	// Output:
+	// [3 3 3 4] <nil>
+}
+
+func ExamplePruferFromList() {
+	f, _ := graph.PruferFromList([]graph.NI{3, 3, 3, 4})
+	for n, e := range f.Paths {
+		fmt.Println(n, e)
+	}
+	// Output:
+	// 0 {3 4}
+	// 1 {3 4}
+	// 2 {3 4}
+	// 3 {4 3}
+	// 4 {5 2}
+	// 5 {-1 1}
+}
+
+func TestPrufer(t *testing.T) {
+	r := rand.New(rand.NewSource(45))
+	for tc := 0; tc < 100; tc++ {
+		n := 2 + r.Intn(30)
+		s := make([]graph.NI, n-2)
+		for i := range s {
+			s[i] = graph.NI(r.Intn(n))
+		}
+		g, err := graph.PruferUndirected(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if isTree, allTree := g.IsTree(0); !isTree || !allTree {
+			t.Fatal("not a tree", s)
+		}
+		s2, err := g.PruferSequence()
+		if err != nil || !reflect.DeepEqual(s, s2) {
+			t.Fatal("round trip", s, s2, err)
+		}
+		f, _ := graph.PruferFromList(s)
+		f.ReRoot(0)
+		if s3, err := f.PruferSequence(); err != nil || !reflect.DeepEqual(s, s3) {
+			t.Fatal("from list", s, s3, err)
+		}
+	}
+	if _, err := graph.PruferFromList([]graph.NI{0, 4}); err == nil {
+		t.Fatal("out of range accepted")
+	}
+	var c graph.Undirected
+	c.AddEdge(0, 1)
+	c.AddEdge(1, 2)
+	c.AddEdge(2, 0)
+	if _, err := c.PruferSequence(); err == nil {
+		t.Fatal("cycle accepted")
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright 2026 Sonia Keys
+// License MIT: https://opensource.org/licenses/MIT
+
+package graph
+
+// treeiso.go -- tree canonical forms and isomorphism by the algorithm of
+// Aho, Hopcroft, and Ullman.
+
+import (
+	"hash/fnv"
+	"sort"
+	"strings"
+)
+
+// ahu computes AHU classes for the rooted subtree of f at root.
+//
+// Nodes of the subtree are labeled level by level, from the deepest level
+// up.  The label of a node is the rank, among the distinct values at its
+// level, of the sorted list of labels of its children.  Nodes at the same
+// level then have equal labels exactly when their subtrees are isomorphic.
+//
+// Returned are child lists for all nodes of f, where the child lists of
+// nodes in the subtree are sorted by label.
+func (f FromList) ahu(root NI) (ch AdjacencyList) {
+	t, _ := f.Transpose(nil)
+	ch = t.AdjacencyList
+	var levels [][]NI
+	for lv := []NI{root}; len(lv) > 0; {
+		levels = append(levels, lv)
+		var next []NI
+		for _, n := range lv {
+			next = append(next, ch[n]...)
+		}
+		lv = next
+	}
+	label := make([]int, len(ch))
+	cmp := func(a, b NI) int {
+		ca, cb := ch[a], ch[b]
+		for i := 0; i < len(ca) && i < len(cb); i++ {
+			if d := label[ca[i]] - label[cb[i]]; d != 0 {
+				return d
+			}
+		}
+		return len(ca) - len(cb)
+	}
+	for d := len(levels) - 1; d >= 0; d-- {
+		lv := levels[d]
+		for _, n := range lv {
+			to := ch[n]
+			sort.Slice(to, func(i, j int) bool {
+				return label[to[i]] < label[to[j]]
+			})
+		}
+		sort.Slice(lv, func(i, j int) bool { return cmp(lv[i], lv[j]) < 0 })
+		r := 0
+		for i, n := range lv {
+			if i > 0 && cmp(lv[i-1], n) != 0 {
+				r++
+			}
+			label[n] = r
+		}
+	}
+	return
+}
+
+// ahuString returns the parenthesis encoding of the subtree at root of
+// child lists ch, as sorted by ahu.
+func ahuString(ch AdjacencyList, root NI) string {
+	var b strings.Builder
+	type frame struct {
+		n NI
+		x int // index of next child
+	}
+	b.WriteByte('(')
+	stack := []frame{{root, 0}}
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		if to := ch[top.n]; top.x < len(to) {
+			top.x++
+			b.WriteByte('(')
+			stack = append(stack, frame{to[top.x-1], 0})
+			continue
+		}
+		b.WriteByte(')')
+		stack = stack[:len(stack)-1]
+	}
+	return b.String()
+}
+
+// CanonicalTree returns a canonical encoding of the rooted subtree at root.
+//
+// The encoding is that of Aho, Hopcroft, and Ullman, a string of balanced
+// parentheses with one pair for each node of the subtree, with children
+// in a canonical order.  Two rooted trees are isomorphic if and only if
+// their encodings are equal.  Node numbers are not represented in the
+// encoding.  Nodes outside the subtree at root do not contribute to it.
+//
+// Only the From members of f.Paths are used.  The FromList cannot be cyclic.
+//
+// See also TreeHash, TreeIsomorphism, and Undirected.CanonicalTree.
+func (f FromList) CanonicalTree(root NI) string {
+	return ahuString(f.ahu(root), root)
+}
+
+// TreeHash returns a 64-bit hash of the canonical encoding of the rooted
+// subtree at root.
+//
+// Isomorphic trees have equal hashes.  Hashes are suitable for bucketing
+// trees to find duplicates, but equal hashes do not guarantee isomorphism.
+// Confirm with CanonicalTree or TreeIsomorphism as needed.
+func (f FromList) TreeHash(root NI) uint64 {
+	return hashString(f.CanonicalTree(root))
+}
+
+func hashString(s string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	return h.Sum64()
+}
+
+// TreeIsomorphism finds an isomorphism between the rooted subtree of f at
+// root and the rooted subtree of f2 at root2.
+//
+// If the subtrees are isomorphic, TreeIsomorphism returns a mapping m and
+// ok = true.  The mapping has length len(f.Paths).  For nodes n of the
+// subtree of f, m[n] is the corresponding node of f2, with m[root] = root2.
+// For other nodes of f, m[n] is -1.  If the subtrees are not isomorphic,
+// TreeIsomorphism returns ok = false.
+//
+// Only the From members of the FromLists are used.  The FromLists cannot be
+// cyclic.
+func (f FromList) TreeIsomorphism(root NI, f2 FromList, root2 NI) (m []NI, ok bool) {
+	ch1 := f.ahu(root)
+	ch2 := f2.ahu(root2)
+	if ahuString(ch1, root) != ahuString(ch2, root2) {
+		return nil, false
+	}
+	m = make([]NI, len(ch1))
+	for i := range m {
+		m[i] = -1
+	}
+	mapAHU(ch1, ch2, root, root2, m)
+	return m, true
+}
+
+// mapAHU maps the subtree of ch1 at u to the subtree of ch2 at v, where
+// child lists are sorted by ahu and the encodings of u and v are equal.
+func mapAHU(ch1, ch2 AdjacencyList, u, v NI, m []NI) {
+	type pair struct{ u, v NI }
+	stack := []pair{{u, v}}
+	for len(stack) > 0 {
+		p := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		m[p.u] = p.v
+		for i, c := range ch1[p.u] {
+			stack = append(stack, pair{c, ch2[p.v][i]})
+		}
+	}
+}
+
+// treeCenters returns a FromList of tree g rooted at each center of g.
+//
+// If g is not a connected tree, ok is false.
+func (g Undirected) treeCenters() (fs []FromList, centers []NI, ok bool) {
+	if g.Order() == 0 {
+		return nil, nil, false
+	}
+	if isTree, allTree := g.IsTree(0); !isTree || !allTree {
+		return nil, nil, false
+	}
+	f, _ := g.FromList(0)
+	centers = f.Center()
+	for _, c := range centers {
+		fc := FromList{Paths: append([]PathEnd{}, f.Paths...)}
+		fc.ReRoot(c)
+		fs = append(fs, fc)
+	}
+	return fs, centers, true
+}
+
+// CanonicalTree returns a canonical encoding of unrooted tree g.
+//
+// The tree is rooted at its center.  Where the center consists of two nodes,
+// the lesser of the two rooted encodings is used.  The encoding is as
+// described for FromList.CanonicalTree.  Two unrooted trees are isomorphic
+// if and only if their encodings are equal.
+//
+// Graph g must be connected as a tree.  If it is not, CanonicalTree returns
+// ok = false.
+func (g Undirected) CanonicalTree() (c string, ok bool) {
+	fs, centers, ok := g.treeCenters()
+	if !ok {
+		return "", false
+	}
+	for i, f := range fs {
+		if e := f.CanonicalTree(centers[i]); i == 0 || e < c {
+			c = e
+		}
+	}
+	return c, true
+}
+
+// TreeHash returns a 64-bit hash of the canonical encoding of unrooted tree
+// g.
+//
+// See FromList.TreeHash for considerations on using hashes.  Graph g must be
+// connected as a tree.  If it is not, TreeHash returns ok = false.
+func (g Undirected) TreeHash() (h uint64, ok bool) {
+	c, ok := g.CanonicalTree()
+	if !ok {
+		return 0, false
+	}
+	return hashString(c), true
+}
+
+// TreeIsomorphism finds an isomorphism between unrooted trees g and h.
+//
+// If g and h are isomorphic, TreeIsomorphism returns a mapping m from nodes
+// of g to nodes of h, and ok = true.  Edge {n1, n2} of g corresponds to edge
+// {m[n1], m[n2]} of h.  If g and h are not isomorphic, or either is not
+// connected as a tree, TreeIsomorphism returns ok = false.
+func (g Undirected) TreeIsomorphism(h Undirected) (m []NI, ok bool) {
+	if g.Order() != h.Order() {
+		return nil, false
+	}
+	fg, cg, ok := g.treeCenters()
+	if !ok {
+		return nil, false
+	}
+	fh, ch, ok := h.treeCenters()
+	if !ok || len(cg) != len(ch) {
+		return nil, false
+	}
+	// fix the rooting of g and try each center of h.
+	for i := range fh {
+		if m, ok = fg[0].TreeIsomorphism(cg[0], fh[i], ch[i]); ok {
+			return
+		}
+	}
+	return nil, false
+}
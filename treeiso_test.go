@@ -0,0 +1,140 @@
+// Copyright 2026 Sonia Keys
+// License MIT: https://opensource.org/licenses/MIT
+
+package graph_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/soniakeys/graph"
+)
+
+func ExampleFromList_CanonicalTree() {
+	//   0       3
+	//  / \     / \
+	// 1   2   4   5
+	// |           |
+	// 6           7
+	f := graph.FromList{Paths: []graph.PathEnd{
+		{From: -1}, {From: 0}, {From: 0},
+		{From: -1}, {From: 3}, {From: 3},
+		{From: 1}, {From: 5},
+	}}
+	fmt.Println(f.CanonicalTree(0))
+	fmt.Println(f.CanonicalTree(3))
+	fmt.Println(f.TreeHash(0) == f.TreeHash(3))
+	// Output:
+	// (()(()))
+	// (()(()))
+	// true
+}
+
+func ExampleFromList_TreeIsomorphism() {
+	//   0       3
+	//  / \     / \
+	// 1   2   4   5
+	// |           |
+	// 6           7
+	f := graph.FromList{Paths: []graph.PathEnd{
+		{From: -1}, {From: 0}, {From: 0},
+		{From: -1}, {From: 3}, {From: 3},
+		{From: 1}, {From: 5},
+	}}
+	fmt.Println(f.TreeIsomorphism(0, f, 3))
+	// Output:
+	// [3 5 4 -1 -1 -1 7 -1] true
+}
+
+func ExampleUndirected_CanonicalTree() {
+	// 0-1-2-3   and   1-0-3
+	//     |             |
+	//     4           4-2
+	var g, h graph.Undirected
+	g.AddEdge(0, 1)
+	g.AddEdge(1, 2)
+	g.AddEdge(2, 3)
+	g.AddEdge(2, 4)
+	h.AddEdge(1, 0)
+	h.AddEdge(0, 3)
+	h.AddEdge(0, 2)
+	h.AddEdge(2, 4)
+	fmt.Println(g.CanonicalTree())
+	fmt.Println(h.CanonicalTree())
+	fmt.Println(g.TreeIsomorphism(h))
+	// Output:
+	// (()(()())) true
+	// (()(()())) true
+	// [4 2 0 1 3] true
+}
+
+func TestTreeIsomorphism(t *testing.T) {
+	r := rand.New(rand.NewSource(44))
+	for tc := 0; tc < 50; tc++ {
+		n := 1 + r.Intn(40)
+		var g graph.Undirected
+		g.AdjacencyList = make(graph.AdjacencyList, n)
+		for i := 1; i < n; i++ {
+			g.AddEdge(graph.NI(i), graph.NI(r.Intn(i)))
+		}
+		// random relabeling
+		p := r.Perm(n)
+		var h graph.Undirected
+		h.AdjacencyList = make(graph.AdjacencyList, n)
+		g.SimpleEdges(func(e graph.Edge) {
+			h.AddEdge(graph.NI(p[e.N1]), graph.NI(p[e.N2]))
+		})
+		cg, _ := g.CanonicalTree()
+		ch, _ := h.CanonicalTree()
+		if cg != ch {
+			t.Fatal("canonical", cg, ch)
+		}
+		m, ok := g.TreeIsomorphism(h)
+		if !ok {
+			t.Fatal("not isomorphic")
+		}
+		g.SimpleEdges(func(e graph.Edge) {
+			if has, _, _ := h.HasEdge(m[e.N1], m[e.N2]); !has {
+				t.Fatal("mapping", e)
+			}
+		})
+		// a cycle is not a tree
+		if n > 2 {
+			h.AddEdge(0, 1)
+			h.AddEdge(1, 2)
+			h.AddEdge(2, 0)
+			if _, ok := h.TreeIsomorphism(g); ok {
+				t.Fatal("cyclic")
+			}
+		}
+	}
+}
+
+func TestTreeIsomorphismSubtree(t *testing.T) {
+	// nodes outside the subtree at root do not affect the encoding
+	f := graph.FromList{Paths: []graph.PathEnd{
+		{From: -1}, {From: 0}, {From: 1}, {From: 0}, {From: 3},
+	}}
+	if c := f.CanonicalTree(1); c != "(())" {
+		t.Fatal("CanonicalTree(1)", c)
+	}
+	m, ok := f.TreeIsomorphism(1, f, 3)
+	if !ok || m[1] != 3 || m[2] != 4 || m[0] != -1 {
+		t.Fatal("TreeIsomorphism", m, ok)
+	}
+	// a deep path must encode in linear time and space
+	const n = 100000
+	p := graph.NewFromList(n)
+	p.Paths[0].From = -1
+	for i := 1; i < n; i++ {
+		p.Paths[i].From = graph.NI(i - 1)
+	}
+	c := p.CanonicalTree(0)
+	if len(c) != 2*n || c[:2] != "((" || c[2*n-2:] != "))" {
+		t.Fatal("path encoding")
+	}
+	if p.TreeHash(0) == p.TreeHash(1) {
+		t.Fatal("path hash")
+	}
+}
@@ -1,18 +1,30 @@
 // Copyright 2016 Sonia Keys
 // License MIT: http://opensource.org/licenses/MIT
 
-// Treevis draws trees with text.  Capabilities are currently quite limited.
+// Treevis draws trees with text.
+//
+// Trees can be drawn with an indented layout, the default, or a top-down
+// layout.  Trees can be given as a graph.Directed or graph.LabeledDirected
+// with a root node, as a graph.FromList such as returned by searches and
+// spanning tree algorithms, or as graph.Dominators.
 package treevis
 
 import (
 	"fmt"
 	"io"
 	"math/big"
+	"sort"
 	"strconv"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/soniakeys/graph"
 )
 
+// G holds glyphs used for the indented layout.
+//
+// Elided is written in place of the children of a node at the depth limit
+// set with MaxDepth.
 type G struct {
 	Leaf      string
 	NonLeaf   string
@@ -20,10 +32,34 @@ type G struct {
 	Vertical  string
 	LastChild string
 	Indent    string
+	Elided    string
 }
 
+// Layout selects the arrangement of a drawn tree.
+type Layout int
+
+const (
+	// Indented draws one node per line, children indented below parents.
+	Indented Layout = iota
+	// TopDown draws the root at the top with children in rows below,
+	// connected with ASCII characters.
+	TopDown
+)
+
+// Config holds options for drawing trees.
+//
+// Annotate, if non-nil, gives an annotation written after each node label.
+// ArcLabel, if non-nil, formats arc labels of labeled trees.  An arc label
+// is written just before the node label of the node the arc leads to.
+// Compare, if non-nil, orders children of each node.  MaxDepth, if greater
+// than zero, limits the number of levels drawn below the root.
 type Config struct {
 	NodeLabel func(graph.NI) string
+	Annotate  func(graph.NI) string
+	ArcLabel  func(graph.LI) string
+	Compare   func(a, b graph.NI) int
+	MaxDepth  int
+	Layout    Layout
 	Glyphs    G
 }
 
@@ -36,6 +72,7 @@ var Defaults = Config{
 		Vertical:  "│ ",
 		LastChild: "└─",
 		Indent:    "  ",
+		Elided:    "…",
 	},
 }
 
@@ -49,43 +86,381 @@ func Glyphs(g G) Option {
 	return func(c *Config) { c.Glyphs = g }
 }
 
-func Write(g graph.Directed, root graph.NI, w io.Writer, options ...Option) (err error) {
-	cf := Defaults
-	for _, o := range options {
-		o(&cf)
-	}
-	var vis big.Int
-	var f func(graph.NI, string) bool
-	f = func(n graph.NI, pre string) bool {
+// ArcLabel sets a function for formatting arc labels of labeled trees.
+func ArcLabel(f func(graph.LI) string) Option {
+	return func(c *Config) { c.ArcLabel = f }
+}
+
+// SortChildren orders the children of each node with compare function cmp.
+//
+// Function cmp returns a negative number when a should precede b, a
+// positive number when b should precede a, and zero otherwise.  Children
+// comparing equal keep their original order.
+func SortChildren(cmp func(a, b graph.NI) int) Option {
+	return func(c *Config) { c.Compare = cmp }
+}
+
+// MaxDepth limits the number of levels drawn below the root.
+//
+// Children of nodes at depth d are elided.  In the indented layout they are
+// replaced with the Elided glyph.  In the top-down layout they are
+// replaced with "…".
+func MaxDepth(d int) Option {
+	return func(c *Config) { c.MaxDepth = d }
+}
+
+// Annotate sets a function for annotating nodes.
+//
+// The annotation of a node is written just after its node label.  It can
+// show values such as distances, postorder numbers, or subtree sizes.
+func Annotate(f func(graph.NI) string) Option {
+	return func(c *Config) { c.Annotate = f }
+}
+
+// WithLayout selects a layout.
+func WithLayout(l Layout) Option {
+	return func(c *Config) { c.Layout = l }
+}
+
+// child is an arc to a child node.
+type child struct {
+	n graph.NI
+	l graph.LI
+}
+
+// tnode is a node of a tree prepared for drawing.
+type tnode struct {
+	label   string
+	kids    []*tnode
+	elided  bool
+	nonTree bool // marks the point where a non-tree was detected
+
+	w, c int // top-down layout width and center column
+}
+
+var errNonTree = fmt.Errorf("non-tree")
+
+// build prepares the tree at root for drawing.
+//
+// Argument labeled indicates arc labels of children are meaningful.
+// If a node is reached twice, the returned tree ends with a nonTree node.
+func (cf *Config) build(root graph.NI, kids func(graph.NI) []child, labeled bool, vis *big.Int) (t *tnode, ok bool) {
+	var f func(n graph.NI, arc string, depth int) (*tnode, bool)
+	f = func(n graph.NI, arc string, depth int) (*tnode, bool) {
 		if vis.Bit(int(n)) != 0 {
+			return &tnode{nonTree: true}, false
+		}
+		vis.SetBit(vis, int(n), 1)
+		t := &tnode{label: arc + cf.NodeLabel(n)}
+		if cf.Annotate != nil {
+			t.label += cf.Annotate(n)
+		}
+		ch := kids(n)
+		if len(ch) == 0 {
+			return t, true
+		}
+		if cf.MaxDepth > 0 && depth == cf.MaxDepth {
+			t.elided = true
+			return t, true
+		}
+		if cf.Compare != nil {
+			ch = append([]child{}, ch...)
+			sort.SliceStable(ch, func(i, j int) bool {
+				return cf.Compare(ch[i].n, ch[j].n) < 0
+			})
+		}
+		for _, c := range ch {
+			arc := ""
+			if labeled && cf.ArcLabel != nil {
+				arc = cf.ArcLabel(c.l)
+			}
+			k, ok := f(c.n, arc, depth+1)
+			t.kids = append(t.kids, k)
+			if !ok {
+				return t, false
+			}
+		}
+		return t, true
+	}
+	return f(root, "", 0)
+}
+
+func (cf *Config) write(t *tnode, ok bool, w io.Writer) error {
+	if cf.Layout == TopDown {
+		if !ok {
+			return errNonTree
+		}
+		return cf.writeTopDown(t, w)
+	}
+	return cf.writeIndented(t, w)
+}
+
+func (cf *Config) writeIndented(t *tnode, w io.Writer) (err error) {
+	var f func(*tnode, string) bool
+	f = func(t *tnode, pre string) bool {
+		if t.nonTree {
 			fmt.Fprintln(w, "%!(NONTREE)")
-			err = fmt.Errorf("non-tree")
+			err = errNonTree
 			return false
 		}
-		vis.SetBit(&vis, int(n), 1)
-		to := g.AdjacencyList[n]
-		if len(to) == 0 {
-			_, err = fmt.Fprint(w, cf.Glyphs.Leaf, cf.NodeLabel(n), "\n")
+		if len(t.kids) == 0 && !t.elided {
+			_, err = fmt.Fprint(w, cf.Glyphs.Leaf, t.label, "\n")
 			return err == nil
 		}
-		_, err = fmt.Fprint(w, cf.Glyphs.NonLeaf, cf.NodeLabel(n), "\n")
+		_, err = fmt.Fprint(w, cf.Glyphs.NonLeaf, t.label, "\n")
 		if err != nil {
 			return false
 		}
-		last := len(to) - 1
-		for _, to := range to[:last] {
+		if t.elided {
+			_, err = fmt.Fprint(w, pre, cf.Glyphs.LastChild, cf.Glyphs.Elided, "\n")
+			return err == nil
+		}
+		last := len(t.kids) - 1
+		for _, k := range t.kids[:last] {
 			if _, err = fmt.Fprint(w, pre, cf.Glyphs.Child); err != nil {
 				return false
 			}
-			if !f(to, pre+cf.Glyphs.Vertical) {
+			if !f(k, pre+cf.Glyphs.Vertical) {
 				return false
 			}
 		}
 		if _, err = fmt.Fprint(w, pre, cf.Glyphs.LastChild); err != nil {
 			return false
 		}
-		return f(to[last], pre+cf.Glyphs.Indent)
+		return f(t.kids[last], pre+cf.Glyphs.Indent)
 	}
-	f(root, "")
+	f(t, "")
 	return
 }
+
+// gap is the number of spaces between subtrees in the top-down layout.
+const gap = 3
+
+func (cf *Config) writeTopDown(t *tnode, w io.Writer) error {
+	var size func(*tnode)
+	size = func(t *tnode) {
+		if t.elided {
+			t.kids = []*tnode{{label: "…"}}
+		}
+		t.w = utf8.RuneCountInString(t.label)
+		if len(t.kids) == 0 {
+			return
+		}
+		sum := -gap
+		for _, k := range t.kids {
+			size(k)
+			sum += k.w + gap
+		}
+		if sum > t.w {
+			t.w = sum
+		}
+	}
+	size(t)
+	var rows [][]rune
+	put := func(row, col int, s string) {
+		for len(rows) <= row {
+			rows = append(rows, nil)
+		}
+		r := rows[row]
+		for _, c := range s {
+			for len(r) <= col {
+				r = append(r, ' ')
+			}
+			r[col] = c
+			col++
+		}
+		rows[row] = r
+	}
+	var place func(t *tnode, left, depth int)
+	place = func(t *tnode, left, depth int) {
+		lw := utf8.RuneCountInString(t.label)
+		if len(t.kids) == 0 {
+			t.c = left + (lw-1)/2
+		} else {
+			sum := -gap
+			for _, k := range t.kids {
+				sum += k.w + gap
+			}
+			x := left + (t.w-sum)/2
+			for _, k := range t.kids {
+				place(k, x, depth+1)
+				x += k.w + gap
+			}
+			if k := len(t.kids); k%2 == 1 {
+				t.c = t.kids[k/2].c
+			} else {
+				t.c = (t.kids[0].c + t.kids[k-1].c) / 2
+			}
+		}
+		s := t.c - (lw-1)/2
+		if s < left {
+			s = left
+		} else if s+lw > left+t.w {
+			s = left + t.w - lw
+		}
+		row := 3 * depth
+		put(row, s, t.label)
+		if len(t.kids) == 0 {
+			return
+		}
+		put(row+1, t.c, "|")
+		first, last := t.kids[0].c, t.kids[len(t.kids)-1].c
+		if len(t.kids) == 1 && first == t.c {
+			put(row+2, t.c, "|")
+			return
+		}
+		lo, hi := first, last
+		if t.c < lo {
+			lo = t.c
+		}
+		if t.c > hi {
+			hi = t.c
+		}
+		put(row+2, lo, strings.Repeat("-", hi-lo+1))
+		put(row+2, t.c, "+")
+		for _, k := range t.kids {
+			put(row+2, k.c, "+")
+		}
+	}
+	place(t, 0, 0)
+	for _, r := range rows {
+		if _, err := fmt.Fprintln(w, strings.TrimRight(string(r), " ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Write draws the tree of g at root.
+func Write(g graph.Directed, root graph.NI, w io.Writer, options ...Option) (err error) {
+	cf := Defaults
+	for _, o := range options {
+		o(&cf)
+	}
+	kids := func(n graph.NI) []child {
+		to := g.AdjacencyList[n]
+		c := make([]child, len(to))
+		for i, to := range to {
+			c[i] = child{n: to}
+		}
+		return c
+	}
+	var vis big.Int
+	t, ok := cf.build(root, kids, false, &vis)
+	return cf.write(t, ok, w)
+}
+
+// WriteLabeled draws the tree of labeled graph g at root.
+//
+// Arc labels are written if option ArcLabel is given.
+func WriteLabeled(g graph.LabeledDirected, root graph.NI, w io.Writer, options ...Option) (err error) {
+	cf := Defaults
+	for _, o := range options {
+		o(&cf)
+	}
+	kids := func(n graph.NI) []child {
+		to := g.LabeledAdjacencyList[n]
+		c := make([]child, len(to))
+		for i, to := range to {
+			c[i] = child{to.To, to.Label}
+		}
+		return c
+	}
+	var vis big.Int
+	t, ok := cf.build(root, kids, true, &vis)
+	return cf.write(t, ok, w)
+}
+
+// WriteFromList draws the trees of FromList f.
+//
+// Each node with a From value of -1 is a root.  Trees are drawn one after
+// another in order of root node number.
+//
+// FromLists returned by searches such as BreadthFirst or Prim populate Len
+// values and mark nodes not reached with a Len of 0.  If any Len value of
+// f is nonzero, nodes with a Len of 0 are not drawn.  Otherwise Len values
+// are ignored and all nodes are drawn.
+//
+// Only the From and Len members of f.Paths are used.
+func WriteFromList(f graph.FromList, w io.Writer, options ...Option) (err error) {
+	return writeFromList(f, nil, w, options)
+}
+
+// WriteFromListLabeled draws the trees of FromList f with arc labels.
+//
+// Labels[n] is the label of the arc from f.Paths[n].From to n, as populated
+// for example by Prim.  Arc labels are written if option ArcLabel is given.
+// Otherwise WriteFromListLabeled is the same as WriteFromList.
+func WriteFromListLabeled(f graph.FromList, labels []graph.LI, w io.Writer, options ...Option) (err error) {
+	return writeFromList(f, labels, w, options)
+}
+
+func writeFromList(f graph.FromList, labels []graph.LI, w io.Writer, options []Option) error {
+	p := f.Paths
+	useLen := false
+	for _, e := range p {
+		if e.Len > 0 {
+			useLen = true
+			break
+		}
+	}
+	parent := make([]graph.NI, len(p))
+	for n, e := range p {
+		parent[n] = e.From
+		if useLen && e.Len == 0 {
+			parent[n] = -2 // not drawn
+		}
+	}
+	return writeParents(parent, labels, w, options)
+}
+
+// writeParents draws trees of a parent list where roots have parent -1.
+//
+// Nodes with parents < -1 are not drawn.
+func writeParents(parent []graph.NI, labels []graph.LI, w io.Writer, options []Option) error {
+	cf := Defaults
+	for _, o := range options {
+		o(&cf)
+	}
+	ch := make([][]child, len(parent))
+	for n, fr := range parent {
+		if fr >= 0 {
+			c := child{n: graph.NI(n)}
+			if labels != nil {
+				c.l = labels[n]
+			}
+			ch[fr] = append(ch[fr], c)
+		}
+	}
+	kids := func(n graph.NI) []child { return ch[n] }
+	var vis big.Int
+	for n, fr := range parent {
+		if fr != -1 {
+			continue
+		}
+		t, ok := cf.build(graph.NI(n), kids, labels != nil, &vis)
+		if err := cf.write(t, ok, w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteDominators draws the dominator tree of d.
+//
+// The tree is rooted at the start node used to compute the dominators.
+// Nodes not reachable from the start node are not drawn.
+func WriteDominators(d graph.Dominators, w io.Writer, options ...Option) (err error) {
+	parent := make([]graph.NI, len(d.Immediate))
+	for n, im := range d.Immediate {
+		switch {
+		case im < 0:
+			parent[n] = -2
+		case im == graph.NI(n):
+			parent[n] = -1
+		default:
+			parent[n] = im
+		}
+	}
+	return writeParents(parent, nil, w, options)
+}
@@ -4,7 +4,10 @@
 package treevis_test
 
 import (
+	"cmp"
+	"fmt"
 	"os"
+	"strconv"
 
 	"github.com/soniakeys/graph"
 	"github.com/soniakeys/graph/treevis"
@@ -82,3 +85,169 @@ func ExampleNodeLabel() {
 	// ├─╴ b
 	// └─╴ c
 }
+
+func ExampleWriteFromList() {
+	// a forest of two trees
+	f := graph.FromList{Paths: []graph.PathEnd{
+		{From: -1, Len: 1},
+		{From: 0, Len: 2},
+		{From: -1, Len: 1},
+		{From: 2, Len: 2},
+		{From: 0, Len: 2},
+	}}
+	treevis.WriteFromList(f, os.Stdout)
+	// Output:
+	// ┐0
+	// ├─╴1
+	// └─╴4
+	// ┐2
+	// └─╴3
+}
+
+func ExampleArcLabel() {
+	g := graph.LabeledDirected{graph.LabeledAdjacencyList{
+		0: {{To: 1, Label: 7}, {To: 2, Label: 9}},
+		1: {{To: 3, Label: 4}},
+		3: {},
+	}}
+	treevis.WriteLabeled(g, 0, os.Stdout,
+		treevis.ArcLabel(func(l graph.LI) string {
+			return "(" + strconv.Itoa(int(l)) + ") "
+		}))
+	// Output:
+	// ┐0
+	// ├─┐(7) 1
+	// │ └─╴(4) 3
+	// └─╴(9) 2
+}
+
+func ExampleSortChildren() {
+	g := graph.Directed{graph.AdjacencyList{
+		0: {3, 1, 2},
+		3: {},
+	}}
+	treevis.Write(g, 0, os.Stdout, treevis.SortChildren(cmp.Compare[graph.NI]))
+	// Output:
+	// ┐0
+	// ├─╴1
+	// ├─╴2
+	// └─╴3
+}
+
+func ExampleMaxDepth() {
+	g := graph.Directed{graph.AdjacencyList{
+		0: {1, 2, 3},
+		1: {4, 5},
+		5: {6},
+		6: {},
+	}}
+	treevis.Write(g, 0, os.Stdout, treevis.MaxDepth(1))
+	// Output:
+	// ┐0
+	// ├─┐1
+	// │ └─…
+	// ├─╴2
+	// └─╴3
+}
+
+func ExampleWithLayout() {
+	g := graph.Directed{graph.AdjacencyList{
+		0: {1, 2, 3},
+		1: {4, 5},
+		5: {6},
+		6: {},
+	}}
+	fmt.Println("tree:")
+	treevis.Write(g, 0, os.Stdout, treevis.WithLayout(treevis.TopDown))
+	// Output:
+	// tree:
+	//         0
+	//         |
+	//   +-----+---+
+	//   1     2   3
+	//   |
+	// +-+-+
+	// 4   5
+	//     |
+	//     |
+	//     6
+}
+
+func ExampleWriteFromList_breadthFirst() {
+	g := graph.Directed{graph.AdjacencyList{
+		0: {1, 2},
+		1: {3},
+		2: {3},
+		3: {},
+		4: {0}, // not reachable from 0
+	}}
+	var f graph.FromList
+	g.BreadthFirst(0, graph.From(&f))
+	treevis.WriteFromList(f, os.Stdout)
+	// Output:
+	// ┐0
+	// ├─┐1
+	// │ └─╴3
+	// └─╴2
+}
+
+func ExampleWriteDominators() {
+	g := graph.Directed{graph.AdjacencyList{
+		0: {1, 2},
+		1: {3},
+		2: {3},
+		3: {4},
+		4: {},
+	}}
+	treevis.WriteDominators(g.Dominators(0), os.Stdout)
+	// Output:
+	// ┐0
+	// ├─╴1
+	// ├─╴2
+	// └─┐3
+	//   └─╴4
+}
+
+func ExampleWriteFromListLabeled() {
+	// a minimum spanning tree by Prim
+	g := graph.LabeledUndirected{}
+	w := []float64{4, 1, 2, 5}
+	g.AddEdge(graph.Edge{0, 1}, 0)
+	g.AddEdge(graph.Edge{0, 2}, 1)
+	g.AddEdge(graph.Edge{2, 1}, 2)
+	g.AddEdge(graph.Edge{1, 3}, 3)
+	var f graph.FromList
+	labels := make([]graph.LI, g.Order())
+	g.Prim(0, func(l graph.LI) float64 { return w[l] }, &f, labels, nil)
+	treevis.WriteFromListLabeled(f, labels, os.Stdout,
+		treevis.ArcLabel(func(l graph.LI) string {
+			return fmt.Sprintf("(%g) ", w[l])
+		}))
+	// Output:
+	// ┐0
+	// └─┐(1) 2
+	//   └─┐(2) 1
+	//     └─╴(5) 3
+}
+
+func ExampleAnnotate() {
+	g := graph.Directed{graph.AdjacencyList{
+		0: {1, 2, 3},
+		1: {4, 5},
+		5: {6},
+		6: {},
+	}}
+	f, _ := g.FromList()
+	size := f.SubtreeSizes()
+	treevis.Write(g, 0, os.Stdout, treevis.Annotate(func(n graph.NI) string {
+		return fmt.Sprintf(" (size %d)", size[n])
+	}))
+	// Output:
+	// ┐0 (size 7)
+	// ├─┐1 (size 4)
+	// │ ├─╴4 (size 1)
+	// │ └─┐5 (size 2)
+	// │   └─╴6 (size 1)
+	// ├─╴2 (size 1)
+	// └─╴3 (size 1)
+}
@@ -8,6 +8,7 @@ package graph
 
 import (
 	"fmt"
+	"iter"
 
 	"github.com/soniakeys/bits"
 )
@@ -123,31 +124,47 @@ type EdgeVisitor func(e Edge)
 // See also Undirected.SimpleEdges for a version that emits only the simple
 // subgraph.
 func (g Undirected) Edges(v EdgeVisitor) {
-	a := g.AdjacencyList
-	unpaired := make(AdjacencyList, len(a))
-	for fr, to := range a {
-	arc: // for each arc in a
-		for _, to := range to {
-			if to == NI(fr) {
-				v(Edge{NI(fr), to}) // output loop
-				continue
-			}
-			// search unpaired arcs
-			ut := unpaired[to]
-			for i, u := range ut {
-				if u == NI(fr) { // found reciprocal
-					v(Edge{u, to}) // output edge
-					last := len(ut) - 1
-					ut[i] = ut[last]
-					unpaired[to] = ut[:last]
-					continue arc
+	for e := range g.EdgesSeq() {
+		v(e)
+	}
+}
+
+// EdgesSeq returns an iterator over the edges of an undirected graph.
+//
+// The iterator yields the edges that Edges would pass to its visitor.
+// Breaking out of a range loop over the iterator stops the iteration.
+func (g Undirected) EdgesSeq() iter.Seq[Edge] {
+	return func(yield func(Edge) bool) {
+		a := g.AdjacencyList
+		unpaired := make(AdjacencyList, len(a))
+		for fr, to := range a {
+		arc: // for each arc in a
+			for _, to := range to {
+				if to == NI(fr) {
+					if !yield(Edge{NI(fr), to}) { // output loop
+						return
+					}
+					continue
 				}
+				// search unpaired arcs
+				ut := unpaired[to]
+				for i, u := range ut {
+					if u == NI(fr) { // found reciprocal
+						if !yield(Edge{u, to}) { // output edge
+							return
+						}
+						last := len(ut) - 1
+						ut[i] = ut[last]
+						unpaired[to] = ut[:last]
+						continue arc
+					}
+				}
+				// reciprocal not found
+				unpaired[fr] = append(unpaired[fr], to)
 			}
-			// reciprocal not found
-			unpaired[fr] = append(unpaired[fr], to)
 		}
+		// undefined behavior is that unpaired arcs are silently ignored.
 	}
-	// undefined behavior is that unpaired arcs are silently ignored.
 }
 
 // HasEdge returns true if g has any edge between nodes n1 and n2.
@@ -175,16 +192,31 @@ func (g Undirected) HasEdge(n1, n2 NI) (has bool, x1, x2 int) {
 //
 // See also Undirected.Edges for a version that emits all edges.
 func (g Undirected) SimpleEdges(v EdgeVisitor) {
-	for fr, to := range g.AdjacencyList {
-		e := bits.New(len(g.AdjacencyList))
-		for _, to := range to {
-			if to > NI(fr) && e.Bit(int(to)) == 0 {
-				e.SetBit(int(to), 1)
-				v(Edge{NI(fr), to})
+	for e := range g.SimpleEdgesSeq() {
+		v(e)
+	}
+}
+
+// SimpleEdgesSeq returns an iterator over the edges of the simple subgraph
+// of an undirected graph.
+//
+// The iterator yields the edges that SimpleEdges would pass to its visitor.
+// Breaking out of a range loop over the iterator stops the iteration.
+func (g Undirected) SimpleEdgesSeq() iter.Seq[Edge] {
+	return func(yield func(Edge) bool) {
+		for fr, to := range g.AdjacencyList {
+			e := bits.New(len(g.AdjacencyList))
+			for _, to := range to {
+				if to > NI(fr) && e.Bit(int(to)) == 0 {
+					e.SetBit(int(to), 1)
+					if !yield(Edge{NI(fr), to}) {
+						return
+					}
+				}
 			}
 		}
+		// undefined behavior is that unpaired arcs may or may not be emitted.
 	}
-	// undefined behavior is that unpaired arcs may or may not be emitted.
 }
 
 // TarjanBiconnectedComponents decomposes a graph into maximal biconnected
@@ -382,29 +414,47 @@ type LabeledEdgeVisitor func(e LabeledEdge)
 // See also Undirected.Edges for an unlabeled version.
 // See also the more simplistic LabeledAdjacencyList.ArcsAsEdges.
 func (g LabeledUndirected) Edges(v LabeledEdgeVisitor) {
-	// similar code in LabeledAdjacencyList.InUndirected
-	a := g.LabeledAdjacencyList
-	unpaired := make(LabeledAdjacencyList, len(a))
-	for fr, to := range a {
-	arc: // for each arc in a
-		for _, to := range to {
-			if to.To == NI(fr) {
-				v(LabeledEdge{Edge{NI(fr), to.To}, to.Label}) // output loop
-				continue
-			}
-			// search unpaired arcs
-			ut := unpaired[to.To]
-			for i, u := range ut {
-				if u.To == NI(fr) && u.Label == to.Label { // found reciprocal
-					v(LabeledEdge{Edge{NI(fr), to.To}, to.Label}) // output edge
-					last := len(ut) - 1
-					ut[i] = ut[last]
-					unpaired[to.To] = ut[:last]
-					continue arc
+	for e := range g.EdgesSeq() {
+		v(e)
+	}
+}
+
+// EdgesSeq returns an iterator over the edges of a labeled undirected graph.
+//
+// The iterator yields the edges that Edges would pass to its visitor.
+// Breaking out of a range loop over the iterator stops the iteration.
+func (g LabeledUndirected) EdgesSeq() iter.Seq[LabeledEdge] {
+	return func(yield func(LabeledEdge) bool) {
+		// similar code in LabeledAdjacencyList.InUndirected
+		a := g.LabeledAdjacencyList
+		unpaired := make(LabeledAdjacencyList, len(a))
+		for fr, to := range a {
+		arc: // for each arc in a
+			for _, to := range to {
+				if to.To == NI(fr) {
+					// output loop
+					if !yield(LabeledEdge{Edge{NI(fr), to.To}, to.Label}) {
+						return
+					}
+					continue
+				}
+				// search unpaired arcs
+				ut := unpaired[to.To]
+				for i, u := range ut {
+					if u.To == NI(fr) && u.Label == to.Label { // found reciprocal
+						// output edge
+						if !yield(LabeledEdge{Edge{NI(fr), to.To}, to.Label}) {
+							return
+						}
+						last := len(ut) - 1
+						ut[i] = ut[last]
+						unpaired[to.To] = ut[:last]
+						continue arc
+					}
 				}
+				// reciprocal not found
+				unpaired[fr] = append(unpaired[fr], to)
 			}
-			// reciprocal not found
-			unpaired[fr] = append(unpaired[fr], to)
 		}
 	}
 }
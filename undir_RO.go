@@ -5,6 +5,7 @@ package graph
 
 import (
 	"errors"
+	"iter"
 
 	"github.com/soniakeys/bits"
 )
@@ -78,7 +79,8 @@ func (g Undirected) Bipartite(n NI) (b bool, c1, c2 bits.Bits, oc []NI) {
 //
 // The method calls the emit argument for each maximal clique in g, as long
 // as emit returns true.  If emit returns false, BronKerbosch1 returns
-// immediately.
+// immediately.  The bits passed to emit are reused by the method and are
+// valid only for the duration of the call.  Copy them to retain a clique.
 //
 // There are equivalent labeled and unlabeled versions of this method.
 //
@@ -128,6 +130,23 @@ func (g Undirected) BronKerbosch1(emit func(bits.Bits) bool) {
 	f(R, P, X)
 }
 
+// BronKerbosch1Seq returns an iterator over maximal cliques of g.
+//
+// The iterator yields the cliques that BronKerbosch1 would pass to its emit
+// function.  Unlike the bits passed to emit, each yielded clique is newly
+// allocated and may be retained.
+//
+// There are equivalent labeled and unlabeled versions of this method.
+func (g Undirected) BronKerbosch1Seq() iter.Seq[bits.Bits] {
+	return func(yield func(bits.Bits) bool) {
+		g.BronKerbosch1(func(c bits.Bits) bool {
+			r := bits.New(c.Num)
+			r.Set(c)
+			return yield(r)
+		})
+	}
+}
+
 // BKPivotMaxDegree is a strategy for BronKerbosch methods.
 //
 // To use it, take the method value (see golang.org/ref/spec#Method_values)
@@ -187,7 +206,8 @@ func (g Undirected) BKPivotMinP(P, X bits.Bits) NI {
 //
 // The method calls the emit argument for each maximal clique in g, as long
 // as emit returns true.  If emit returns false, BronKerbosch1 returns
-// immediately.
+// immediately.  The bits passed to emit are reused by the method and are
+// valid only for the duration of the call.  Copy them to retain a clique.
 //
 // There are equivalent labeled and unlabeled versions of this method.
 //
@@ -244,6 +264,23 @@ func (g Undirected) BronKerbosch2(pivot func(P, X bits.Bits) NI, emit func(bits.
 	f(R, P, X)
 }
 
+// BronKerbosch2Seq returns an iterator over maximal cliques of g.
+//
+// The iterator yields the cliques that BronKerbosch2 would pass to its emit
+// function.  Unlike the bits passed to emit, each yielded clique is newly
+// allocated and may be retained.  Argument pivot is as for BronKerbosch2.
+//
+// There are equivalent labeled and unlabeled versions of this method.
+func (g Undirected) BronKerbosch2Seq(pivot func(P, X bits.Bits) NI) iter.Seq[bits.Bits] {
+	return func(yield func(bits.Bits) bool) {
+		g.BronKerbosch2(pivot, func(c bits.Bits) bool {
+			r := bits.New(c.Num)
+			r.Set(c)
+			return yield(r)
+		})
+	}
+}
+
 // BronKerbosch3 finds maximal cliques in an undirected graph.
 //
 // The graph must not contain parallel edges or loops.
@@ -260,7 +297,8 @@ func (g Undirected) BronKerbosch2(pivot func(P, X bits.Bits) NI, emit func(bits.
 //
 // The method calls the emit argument for each maximal clique in g, as long
 // as emit returns true.  If emit returns false, BronKerbosch1 returns
-// immediately.
+// immediately.  The bits passed to emit are reused by the method and are
+// valid only for the duration of the call.  Copy them to retain a clique.
 //
 // There are equivalent labeled and unlabeled versions of this method.
 //
@@ -339,6 +377,23 @@ func (g Undirected) BronKerbosch3(pivot func(P, X bits.Bits) NI, emit func(bits.
 	}
 }
 
+// BronKerbosch3Seq returns an iterator over maximal cliques of g.
+//
+// The iterator yields the cliques that BronKerbosch3 would pass to its emit
+// function.  Unlike the bits passed to emit, each yielded clique is newly
+// allocated and may be retained.  Argument pivot is as for BronKerbosch3.
+//
+// There are equivalent labeled and unlabeled versions of this method.
+func (g Undirected) BronKerbosch3Seq(pivot func(P, X bits.Bits) NI) iter.Seq[bits.Bits] {
+	return func(yield func(bits.Bits) bool) {
+		g.BronKerbosch3(pivot, func(c bits.Bits) bool {
+			r := bits.New(c.Num)
+			r.Set(c)
+			return yield(r)
+		})
+	}
+}
+
 // ConnectedComponentbits.Bits returns a function that iterates over connected
 // components of g, returning a member bitmap for each.
 //
@@ -423,6 +478,26 @@ func (g Undirected) ConnectedComponentLists() func() (nodes []NI, arcSize int) {
 	}
 }
 
+// ConnectedComponentListsSeq returns an iterator over connected components
+// of g.
+//
+// The iterator yields the node list and arc size of each component, as
+// returned by successive calls of the function returned by
+// ConnectedComponentLists.
+//
+// There are equivalent labeled and unlabeled versions of this method.
+func (g Undirected) ConnectedComponentListsSeq() iter.Seq2[[]NI, int] {
+	return func(yield func([]NI, int) bool) {
+		next := g.ConnectedComponentLists()
+		for {
+			nodes, arcSize := next()
+			if nodes == nil || !yield(nodes, arcSize) {
+				return
+			}
+		}
+	}
+}
+
 // ConnectedComponentReps returns a representative node from each connected
 // component of g.
 //
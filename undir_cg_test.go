@@ -13,6 +13,7 @@ package graph_test
 import (
 	"fmt"
 	"log"
+	"slices"
 
 	"github.com/soniakeys/bits"
 	"github.com/soniakeys/graph"
@@ -641,3 +642,28 @@ func ExampleLabeledUndirected_Size() {
 	// Size: 2
 	// (Arc size = 3)
 }
+
+func ExampleLabeledUndirected_BronKerbosch3Seq() {
+	// 0--4--5-
+	//    |  | \
+	//    3--2--1
+	var g graph.LabeledUndirected
+	g.AddEdge(graph.Edge{0, 4}, 0)
+	g.AddEdge(graph.Edge{4, 5}, 0)
+	g.AddEdge(graph.Edge{4, 3}, 0)
+	g.AddEdge(graph.Edge{3, 2}, 0)
+	g.AddEdge(graph.Edge{5, 2}, 0)
+	g.AddEdge(graph.Edge{5, 1}, 0)
+	g.AddEdge(graph.Edge{2, 1}, 0)
+	// yielded cliques may be retained
+	cliques := slices.Collect(g.BronKerbosch3Seq(g.BKPivotMaxDegree))
+	for _, c := range cliques {
+		fmt.Println(c.Slice())
+	}
+	// Output:
+	// [0 4]
+	// [3 4]
+	// [4 5]
+	// [2 3]
+	// [1 2 5]
+}
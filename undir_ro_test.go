@@ -16,6 +16,7 @@ package graph_test
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/soniakeys/bits"
 	"github.com/soniakeys/graph"
@@ -84,6 +85,29 @@ func ExampleUndirected_BronKerbosch1() {
 	// [4 5]
 }
 
+func ExampleUndirected_BronKerbosch1Seq() {
+	// 0--4--5-
+	//    |  | \
+	//    3--2--1
+	var g graph.Undirected
+	g.AddEdge(0, 4)
+	g.AddEdge(4, 5)
+	g.AddEdge(4, 3)
+	g.AddEdge(3, 2)
+	g.AddEdge(5, 2)
+	g.AddEdge(5, 1)
+	g.AddEdge(2, 1)
+	// find a triangle
+	for c := range g.BronKerbosch1Seq() {
+		if c.OnesCount() == 3 {
+			fmt.Println(c.Slice())
+			break
+		}
+	}
+	// Output:
+	// [1 2 5]
+}
+
 func ExampleUndirected_BKPivotMaxDegree() {
 	// 0--4--5-
 	//    |  | \
@@ -230,6 +254,24 @@ func ExampleUndirected_ConnectedComponentLists() {
 	// [2] 0
 }
 
+func ExampleUndirected_ConnectedComponentListsSeq() {
+	//    0   1   2
+	//   / \   \
+	//  3---4   5
+	var g graph.Undirected
+	g.AddEdge(0, 3)
+	g.AddEdge(0, 4)
+	g.AddEdge(3, 4)
+	g.AddEdge(1, 5)
+	for l, ma := range g.ConnectedComponentListsSeq() {
+		fmt.Println(l, ma)
+	}
+	// Output:
+	// [0 3 4] 6
+	// [1 5] 2
+	// [2] 0
+}
+
 func ExampleUndirected_ConnectedComponentReps() {
 	//    0   1   2
 	//   / \   \
@@ -636,3 +678,28 @@ func ExampleUndirected_Size() {
 	// Size: 2
 	// (Arc size = 3)
 }
+
+func ExampleUndirected_BronKerbosch3Seq() {
+	// 0--4--5-
+	//    |  | \
+	//    3--2--1
+	var g graph.Undirected
+	g.AddEdge(0, 4)
+	g.AddEdge(4, 5)
+	g.AddEdge(4, 3)
+	g.AddEdge(3, 2)
+	g.AddEdge(5, 2)
+	g.AddEdge(5, 1)
+	g.AddEdge(2, 1)
+	// yielded cliques may be retained
+	cliques := slices.Collect(g.BronKerbosch3Seq(g.BKPivotMaxDegree))
+	for _, c := range cliques {
+		fmt.Println(c.Slice())
+	}
+	// Output:
+	// [0 4]
+	// [3 4]
+	// [4 5]
+	// [2 3]
+	// [1 2 5]
+}
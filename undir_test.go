@@ -81,6 +81,32 @@ func ExampleUndirected_Edges() {
 	// {2 2}
 }
 
+func ExampleUndirected_EdgesSeq() {
+	//    0
+	//   / \\
+	//  1---2--\
+	//       \-/
+	var g graph.Undirected
+	g.AddEdge(0, 1)
+	g.AddEdge(1, 2)
+	g.AddEdge(2, 0)
+	g.AddEdge(2, 0) // parallel
+	g.AddEdge(2, 2) // loop
+	for e := range g.EdgesSeq() {
+		if e.N1 == e.N2 {
+			fmt.Println("loop at", e.N1)
+			break
+		}
+		fmt.Println(e)
+	}
+	// Output:
+	// {1 0}
+	// {2 1}
+	// {2 0}
+	// {2 0}
+	// loop at 2
+}
+
 func ExampleUndirected_HasEdge() {
 	var g graph.Undirected
 	g.AddEdge(7, 8)
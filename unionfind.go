@@ -0,0 +1,186 @@
+// Copyright 2026 Sonia Keys
+// License MIT: https://opensource.org/licenses/MIT
+
+package graph
+
+type dsElement struct {
+	from NI
+	rank int
+}
+
+// DisjointSet is a union-find data structure over nodes 0 through n-1.
+//
+// Each set is represented as a tree identified by its root node.  Find uses
+// path compression and Union uses union by rank, giving nearly constant
+// amortized time per operation.
+//
+// Construct with NewDisjointSet.
+type DisjointSet struct {
+	set []dsElement
+}
+
+// NewDisjointSet creates a DisjointSet of n singleton sets.
+func NewDisjointSet(n int) DisjointSet {
+	set := make([]dsElement, n)
+	for i := range set {
+		set[i].from = -1
+	}
+	return DisjointSet{set}
+}
+
+// Len returns the number of elements, n, of the DisjointSet.
+func (ds DisjointSet) Len() int {
+	return len(ds.set)
+}
+
+// Union combines the sets containing nodes x and y.
+//
+// Union returns true if disjoint sets were combined, false if x and y were
+// already in the same set.
+func (ds DisjointSet) Union(x, y NI) bool {
+	xr := ds.Find(x)
+	yr := ds.Find(y)
+	if xr == yr {
+		return false
+	}
+	switch xe, ye := &ds.set[xr], &ds.set[yr]; {
+	case xe.rank < ye.rank:
+		xe.from = yr
+	case xe.rank == ye.rank:
+		xe.rank++
+		fallthrough
+	default:
+		ye.from = xr
+	}
+	return true
+}
+
+// Find returns the representative node, the root, of the set containing n.
+func (ds DisjointSet) Find(n NI) NI {
+	// fast paths for n == root or from root.
+	// no updates need in these cases.
+	s := ds.set
+	fr := s[n].from
+	if fr < 0 { // n is root
+		return n
+	}
+	n, fr = fr, s[fr].from
+	if fr < 0 { // n is from root
+		return n
+	}
+	// otherwise updates needed.
+	// two iterative passes (rather than recursion or stack)
+	// pass 1: find root
+	r := fr
+	for {
+		f := s[r].from
+		if f < 0 {
+			break
+		}
+		r = f
+	}
+	// pass 2: update froms
+	for {
+		s[n].from = r
+		if fr == r {
+			return r
+		}
+		n = fr
+		fr = s[n].from
+	}
+}
+
+// Same returns true if nodes x and y are in the same set.
+func (ds DisjointSet) Same(x, y NI) bool {
+	return ds.Find(x) == ds.Find(y)
+}
+
+// Connectivity tracks connected components of an undirected graph as edges
+// are added.
+//
+// It answers connectivity queries without recomputing components as with
+// ConnectedComponentReps.  Edges cannot be removed.
+//
+// Construct with NewConnectivity.
+type Connectivity struct {
+	ds    DisjointSet
+	size  []int // component size, valid at roots
+	count int   // number of components
+}
+
+// NewConnectivity creates a Connectivity for a graph of n nodes and no
+// edges.
+func NewConnectivity(n int) *Connectivity {
+	size := make([]int, n)
+	for i := range size {
+		size[i] = 1
+	}
+	return &Connectivity{NewDisjointSet(n), size, n}
+}
+
+// NewConnectivityUndirected creates a Connectivity initialized with the
+// edges of g.
+func NewConnectivityUndirected(g Undirected) *Connectivity {
+	c := NewConnectivity(g.Order())
+	for fr, to := range g.AdjacencyList {
+		for _, to := range to {
+			c.AddEdge(Edge{NI(fr), to})
+		}
+	}
+	return c
+}
+
+// AddEdge adds edge e.
+//
+// AddEdge returns true if the edge joined two components, false if the end
+// points were already connected.
+func (c *Connectivity) AddEdge(e Edge) bool {
+	r1 := c.ds.Find(e.N1)
+	r2 := c.ds.Find(e.N2)
+	if !c.ds.Union(r1, r2) {
+		return false
+	}
+	c.size[c.ds.Find(r1)] = c.size[r1] + c.size[r2]
+	c.count--
+	return true
+}
+
+// Connected returns true if nodes n1 and n2 are in the same component.
+func (c *Connectivity) Connected(n1, n2 NI) bool {
+	return c.ds.Same(n1, n2)
+}
+
+// Rep returns a representative node of the component containing node n.
+//
+// The representative of a component can change as edges are added.
+func (c *Connectivity) Rep(n NI) NI {
+	return c.ds.Find(n)
+}
+
+// NumComponents returns the number of connected components.
+//
+// Isolated nodes count as components.
+func (c *Connectivity) NumComponents() int {
+	return c.count
+}
+
+// ComponentSize returns the number of nodes in the component containing
+// node n.
+func (c *Connectivity) ComponentSize(n NI) int {
+	return c.size[c.ds.Find(n)]
+}
+
+// Components returns representative nodes and sizes of all components.
+//
+// Reps are in ascending order.  Sizes are parallel to reps.
+func (c *Connectivity) Components() (reps []NI, sizes []int) {
+	reps = make([]NI, 0, c.count)
+	sizes = make([]int, 0, c.count)
+	for n := range c.size {
+		if c.ds.Find(NI(n)) == NI(n) {
+			reps = append(reps, NI(n))
+			sizes = append(sizes, c.size[n])
+		}
+	}
+	return
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 Sonia Keys
+// License MIT: https://opensource.org/licenses/MIT
+
+package graph_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/soniakeys/graph"
+)
+
+func ExampleDisjointSet() {
+	ds := graph.NewDisjointSet(5)
+	fmt.Println(ds.Union(0, 1), ds.Union(3, 4), ds.Union(1, 0))
+	fmt.Println(ds.Same(0, 1), ds.Same(1, 3))
+	fmt.Println(ds.Find(1) == ds.Find(0), ds.Find(2))
+	// Output:
+	// true true false
+	// true false
+	// true 2
+}
+
+func ExampleConnectivity() {
+	c := graph.NewConnectivity(6)
+	for _, e := range []graph.Edge{{0, 1}, {2, 3}, {1, 4}, {4, 0}} {
+		fmt.Println(e, c.AddEdge(e), c.NumComponents())
+	}
+	fmt.Println(c.Connected(0, 4), c.Connected(0, 3))
+	fmt.Println(c.ComponentSize(4), c.ComponentSize(5))
+	fmt.Println(c.Components())
+	// Output:
+	// {0 1} true 5
+	// {2 3} true 4
+	// {1 4} true 3
+	// {4 0} false 3
+	// true false
+	// 3 1
+	// [0 2 5] [3 2 1]
+}
+
+func TestConnectivity(t *testing.T) {
+	r := rand.New(rand.NewSource(36))
+	g := graph.GnmUndirected(200, 150, r)
+	c := graph.NewConnectivityUndirected(g)
+	reps, orders, _ := g.ConnectedComponentReps()
+	if c.NumComponents() != len(reps) {
+		t.Fatal("component count", c.NumComponents(), len(reps))
+	}
+	for i, rep := range reps {
+		if c.ComponentSize(rep) != orders[i] {
+			t.Fatal("component size", rep)
+		}
+	}
+	cc := make([]int, g.Order())
+	it := g.ConnectedComponentLists()
+	for i := 0; ; i++ {
+		nodes, _ := it()
+		if nodes == nil {
+			break
+		}
+		for _, n := range nodes {
+			cc[n] = i
+		}
+	}
+	for n := range cc {
+		for m := range cc {
+			if c.Connected(graph.NI(n), graph.NI(m)) != (cc[n] == cc[m]) {
+				t.Fatal("Connected", n, m)
+			}
+		}
+	}
+}